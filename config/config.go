@@ -18,9 +18,13 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"text/template"
 
+	"github.com/elastic/crd-ref-docs/types"
 	"github.com/goccy/go-yaml"
 )
 
@@ -52,6 +56,35 @@ type ProcessorConfig struct {
 	// alternative field names whenever a struct field carries the json `case:ignore`
 	// tag option. When empty, no aliases are shown for such fields.
 	CaseIgnoreAliases []NamingConvention `json:"caseIgnoreAliases"`
+	// KubeTypes configures the Kubernetes types documented along with the API types.
+	KubeTypes *KubeTypesConfig `json:"kubeTypes"`
+	// Maturity configures the maturity levels and feature gates of fields and types.
+	Maturity *MaturityConfig `json:"maturity"`
+	// OmitDeprecated excludes the deprecated fields and types.
+	OmitDeprecated bool `json:"omitDeprecated"`
+}
+
+// DefaultFeatureGateMarker is the marker setting the feature gate of fields and types, e.g. +featureGate=Foo.
+const DefaultFeatureGateMarker = "featureGate"
+
+type MaturityConfig struct {
+	// FeatureGateMarker is the name of the marker setting the feature gate of fields and types. Defaults to
+	// DefaultFeatureGateMarker.
+	FeatureGateMarker string `json:"featureGateMarker"`
+	// MinLevel excludes the fields and types less mature than the given level, e.g. beta excludes alpha fields.
+	MinLevel types.Maturity `json:"minLevel"`
+}
+
+// KubePackagesRegex matches the packages of the Kubernetes API types.
+const KubePackagesRegex = `^k8s\.io/(?:api|apimachinery|apiextensions-apiserver/pkg/apis)/`
+
+// KubeTypesConfig selects the Kubernetes types that are documented, instead of only linked to the Kubernetes API
+// reference, when referenced by the API types.
+type KubeTypesConfig struct {
+	Include bool `json:"include"`
+	// Packages are RE2 regular expressions matching the packages of the documented Kubernetes types. Defaults to
+	// KubePackagesRegex.
+	Packages []string `json:"packages"`
 }
 
 type Marker struct {
@@ -68,9 +101,53 @@ const (
 )
 
 type RenderConfig struct {
-	KnownTypes        []*KnownType   `json:"knownTypes"`
-	KubernetesVersion string         `json:"kubernetesVersion"`
-	LinkMappings      []*LinkMapping `json:"linkMappings"`
+	KnownTypes []*KnownType `json:"knownTypes"`
+	// LinkRules link the types of whole packages to external documentation. They are checked in order, after the
+	// built-in Kubernetes links and KnownTypes. They are validated when the renderer is created.
+	LinkRules         []*LinkRule `json:"linkRules"`
+	KubernetesVersion string      `json:"kubernetesVersion"`
+	// KubernetesLinks configures the links to the Kubernetes API reference.
+	KubernetesLinks *KubernetesLinksConfig `json:"kubernetesLinks"`
+	LinkMappings    []*LinkMapping         `json:"linkMappings"`
+	// Inventory configures the inventory of the documented types written along with the documentation.
+	Inventory *InventoryConfig `json:"inventory"`
+	// Inventories are the paths of inventories written by other projects, used to link the types they document.
+	Inventories []string `json:"inventories"`
+	// LinkCheck enables the validation of the links of the rendered output.
+	LinkCheck *LinkCheckConfig `json:"linkCheck"`
+	// PkgGoDev links the imported types that are not documented otherwise to pkg.go.dev.
+	PkgGoDev *PkgGoDevConfig `json:"pkgGoDev"`
+	// FieldOrder is the order of the fields of each type. Defaults to source order.
+	FieldOrder types.FieldOrder `json:"fieldOrder"`
+	// TypeOrder is the order of the types of each group version. Defaults to alphabetical order.
+	TypeOrder types.TypeOrder `json:"typeOrder"`
+	// TypeDiagrams enables a diagram of the owned types for each root kind.
+	TypeDiagrams bool `json:"typeDiagrams"`
+	// Site configures the integration of the Markdown output with a static site generator.
+	Site *SiteConfig `json:"site"`
+	// ObservedState configures the rendering of the types describing the observed state of root kinds.
+	ObservedState *ObservedStateConfig `json:"observedState"`
+	// ParseDocComments enables the conversion of the Go doc comment syntax (links, headings, lists and code blocks)
+	// found in doc strings to the markup of the renderer.
+	ParseDocComments bool `json:"parseDocComments"`
+}
+
+// SiteGenerator identifies a static site generator the Markdown output can be integrated with.
+type SiteGenerator string
+
+const (
+	SiteGeneratorHugo       SiteGenerator = "hugo"
+	SiteGeneratorDocusaurus SiteGenerator = "docusaurus"
+	SiteGeneratorMkDocs     SiteGenerator = "mkdocs"
+)
+
+type SiteConfig struct {
+	Generator SiteGenerator `json:"generator"`
+	// Title is the title of the generated section, used in single mode and for the navigation metadata.
+	Title string `json:"title"`
+	// FrontMatter maps front matter keys to templates rendering their values. They are merged with the defaults of
+	// the generator.
+	FrontMatter map[string]string `json:"frontMatter"`
 }
 
 type KnownType struct {
@@ -79,6 +156,87 @@ type KnownType struct {
 	Link    string `json:"link"`
 }
 
+// KubernetesLinkPreset identifies a built-in style of links to the Kubernetes API reference.
+type KubernetesLinkPreset string
+
+const (
+	// KubernetesLinkPresetSinglePage links to the single-page reference generated for each Kubernetes version.
+	KubernetesLinkPresetSinglePage KubernetesLinkPreset = "single-page"
+	// KubernetesLinkPresetPerResource links to the per-resource pages of the Kubernetes API reference.
+	KubernetesLinkPresetPerResource KubernetesLinkPreset = "per-resource"
+)
+
+type KubernetesLinksConfig struct {
+	// Preset is the built-in link style. Defaults to single-page.
+	Preset KubernetesLinkPreset `json:"preset"`
+	// Template overrides the link template of the preset, e.g. to link to a mirror. It can use {{ .kubeVersion }},
+	// {{ .group }}, {{ .version }}, {{ .type }} (the lower-cased type name), {{ .name }}, and the {{ .page }} and
+	// {{ .anchor }} of the type in the per-resource reference.
+	Template string `json:"template"`
+}
+
+// ObservedStateMode is how the types describing the observed state of a kind are rendered.
+type ObservedStateMode string
+
+const (
+	// ObservedStateShow renders the observed state in a section of its own.
+	ObservedStateShow ObservedStateMode = "show"
+	// ObservedStateCollapse renders the observed state in a collapsed section.
+	ObservedStateCollapse ObservedStateMode = "collapse"
+	// ObservedStateOmit leaves the observed state, and the status field of the kind, out of the documentation.
+	ObservedStateOmit ObservedStateMode = "omit"
+)
+
+type ObservedStateConfig struct {
+	// Mode is the rendering of the observed state of all kinds. Defaults to show.
+	Mode ObservedStateMode `json:"mode"`
+	// Kinds overrides Mode for the kinds of the given names.
+	Kinds map[string]ObservedStateMode `json:"kinds"`
+}
+
+// LinkRule links the types of the packages matching Package, optionally restricted to the type names matching one of
+// Names, to the URL rendered from the Link template. The template can use {{ .group }}, {{ .version }}, {{ .name }},
+// {{ .lowerName }} and {{ .package }}.
+type LinkRule struct {
+	Package string   `json:"package"`
+	Names   []string `json:"names"`
+	Link    string   `json:"link"`
+}
+
+// InventoryConfig configures the inventory file mapping the identifiers of the documented types to their URL.
+type InventoryConfig struct {
+	// Path is the path of the inventory file.
+	Path string `json:"path"`
+	// URL is a template rendering the URL of a documented type. It can use {{ .File }}, {{ .Page }} (the file name
+	// without extension), {{ .Anchor }}, {{ .Group }}, {{ .Version }}, {{ .Name }} and {{ .Package }}.
+	URL string `json:"url"`
+}
+
+// LinkCheckConfig configures the validation of the links of the rendered output. Local links to anchors that are
+// not emitted are always reported.
+type LinkCheckConfig struct {
+	// Fail makes rendering fail when broken links are found, instead of only logging them.
+	Fail bool `json:"fail"`
+	// External enables checking that external HTTP(S) links can be fetched.
+	External bool `json:"external"`
+	// Client sends the requests checking external links, e.g. to set up a proxy or authentication. It can only be set
+	// programmatically and defaults to an http.Client with a timeout.
+	Client HTTPClient `json:"-"`
+}
+
+// HTTPClient is the subset of http.Client used to check external links.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// PkgGoDevConfig configures the links to pkg.go.dev. Include and Exclude are RE2 regular expressions matched against
+// the package path of the types: when Include is empty, all packages are included.
+type PkgGoDevConfig struct {
+	Enabled bool     `json:"enabled"`
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
 type LinkMapping struct {
 	URL  string `json:"url"`
 	Link string `json:"link"`
@@ -88,6 +246,9 @@ type LinkMapping struct {
 const (
 	OutputModeSingle = "single"
 	OutputModeGroup  = "group"
+
+	// OutputPathStdout is the output path value that sends the output to stdout.
+	OutputPathStdout = "-"
 )
 
 type Flags struct {
@@ -123,5 +284,73 @@ func Load(flags Flags) (*Config, error) {
 		}
 	}
 
+	switch conf.Render.FieldOrder {
+	case "", types.FieldOrderSource, types.FieldOrderAlphabetical, types.FieldOrderRequiredFirst, types.FieldOrderMarker:
+	default:
+		return nil, fmt.Errorf("render.fieldOrder: unknown field order %q", conf.Render.FieldOrder)
+	}
+
+	switch conf.Render.TypeOrder {
+	case "", types.TypeOrderAlphabetical, types.TypeOrderSource, types.TypeOrderKindsFirst:
+	default:
+		return nil, fmt.Errorf("render.typeOrder: unknown type order %q", conf.Render.TypeOrder)
+	}
+
+	if kl := conf.Render.KubernetesLinks; kl != nil {
+		switch kl.Preset {
+		case "", KubernetesLinkPresetSinglePage, KubernetesLinkPresetPerResource:
+		default:
+			return nil, fmt.Errorf("render.kubernetesLinks.preset: unknown preset %q", kl.Preset)
+		}
+		if _, err := template.New("").Parse(kl.Template); err != nil {
+			return nil, fmt.Errorf("render.kubernetesLinks.template: invalid template: %w", err)
+		}
+	}
+
+	if mc := conf.Processor.Maturity; mc != nil && mc.MinLevel != "" {
+		level, err := types.ParseMaturity(string(mc.MinLevel))
+		if err != nil {
+			return nil, fmt.Errorf("processor.maturity.minLevel: %w", err)
+		}
+		mc.MinLevel = level
+	}
+
+	if obs := conf.Render.ObservedState; obs != nil {
+		if err := obs.Mode.validate(); err != nil {
+			return nil, fmt.Errorf("render.observedState.mode: %w", err)
+		}
+		for kind, mode := range obs.Kinds {
+			if err := mode.validate(); err != nil {
+				return nil, fmt.Errorf("render.observedState.kinds.%s: %w", kind, err)
+			}
+		}
+	}
+
+	if inv := conf.Render.Inventory; inv != nil {
+		if inv.Path == "" {
+			return nil, errors.New("render.inventory.path is required")
+		}
+		if _, err := template.New("").Parse(inv.URL); err != nil {
+			return nil, fmt.Errorf("render.inventory.url: invalid template: %w", err)
+		}
+	}
+
+	if site := conf.Render.Site; site != nil {
+		switch site.Generator {
+		case "", SiteGeneratorHugo, SiteGeneratorDocusaurus, SiteGeneratorMkDocs:
+		default:
+			return nil, fmt.Errorf("render.site.generator: unknown site generator %q", site.Generator)
+		}
+	}
+
 	return &conf, nil
 }
+
+func (m ObservedStateMode) validate() error {
+	switch m {
+	case "", ObservedStateShow, ObservedStateCollapse, ObservedStateOmit:
+		return nil
+	default:
+		return fmt.Errorf("unknown mode %q", m)
+	}
+}
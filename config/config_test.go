@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/elastic/crd-ref-docs/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -89,3 +90,94 @@ func TestLoad_LinkMappingsValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestLoad_ObservedStateValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr bool
+	}{
+		{
+			name: "valid modes",
+			yaml: `render:
+  observedState:
+    mode: collapse
+    kinds:
+      Guestbook: omit
+`,
+		},
+		{
+			name: "unknown mode is rejected",
+			yaml: `render:
+  observedState:
+    mode: hide
+`,
+			wantErr: true,
+		},
+		{
+			name: "unknown kind mode is rejected",
+			yaml: `render:
+  observedState:
+    kinds:
+      Guestbook: hide
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.yaml), 0o600))
+
+			_, err := Load(Flags{Config: path})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoad_MaturityValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    types.Maturity
+		wantErr bool
+	}{
+		{
+			name: "level is normalized",
+			yaml: `processor:
+  maturity:
+    featureGateMarker: k8s:featureGate
+    minLevel: Beta
+`,
+			want: types.MaturityBeta,
+		},
+		{
+			name: "unknown level is rejected",
+			yaml: `processor:
+  maturity:
+    minLevel: ga
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.yaml), 0o600))
+
+			conf, err := Load(Flags{Config: path})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, conf.Processor.Maturity.MinLevel)
+		})
+	}
+}
@@ -49,8 +49,8 @@ func main() {
 	cmd.Flags().StringVar(&args.Config, "config", "config.yaml", "Path to config file")
 	cmd.Flags().StringVar(&args.SourcePath, "source-path", "", "Path to source directory containing CRDs")
 	cmd.Flags().StringVar(&args.TemplatesDir, "templates-dir", "", "Path to the directory containing template files")
-	cmd.Flags().StringVar(&args.Renderer, "renderer", "asciidoctor", "Renderer to use ('asciidoctor' or 'markdown')")
-	cmd.Flags().StringVar(&args.OutputPath, "output-path", ".", "Path to output the rendered result")
+	cmd.Flags().StringVar(&args.Renderer, "renderer", "asciidoctor", "Renderer to use ('asciidoctor', 'markdown' or 'jsonschema')")
+	cmd.Flags().StringVar(&args.OutputPath, "output-path", ".", "Path to output the rendered result, or '-' to write it to stdout")
 	cmd.Flags().StringVar(&args.OutputMode, "output-mode", "single", "Output mode to generate a single file or one file per group ('group' or 'single')")
 	cmd.Flags().IntVar(&args.MaxDepth, "max-depth", 10, "Maximum recursion level for type discovery")
 	cmd.Flags().Var(&args.TemplateKeyValues, "template-value", "Can be used in template to pass in a version number or similar information. Example: --template-value=key1=value1 and {{ markdownTemplateValue \"k1\" }}")
@@ -61,7 +61,7 @@ func main() {
 }
 
 func doRun(_ *cobra.Command, _ []string) error {
-	initLogging(args.LogLevel)
+	initLogging(args.LogLevel, args.OutputPath == config.OutputPathStdout)
 
 	zap.S().Infow("Loading configuration", "path", args.Config)
 	conf, err := config.Load(args)
@@ -98,7 +98,9 @@ func doRun(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
-func initLogging(level string) {
+// initLogging sets up the global logger. When stdout is reserved for the rendered output, all log messages are written
+// to stderr.
+func initLogging(level string, reserveStdout bool) {
 	var logger *zap.Logger
 	var err error
 	errorPriority := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
@@ -123,6 +125,9 @@ func initLogging(level string) {
 
 	consoleErrors := zapcore.Lock(os.Stderr)
 	consoleInfo := zapcore.Lock(os.Stdout)
+	if reserveStdout {
+		consoleInfo = consoleErrors
+	}
 
 	encoderConf := zap.NewDevelopmentEncoderConfig()
 	encoderConf.EncodeLevel = zapcore.CapitalColorLevelEncoder
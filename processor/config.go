@@ -21,6 +21,7 @@ import (
 	"regexp"
 
 	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
 )
 
 func compileConfig(conf *config.Config) (cc *compiledConfig, err error) {
@@ -35,6 +36,7 @@ func compileConfig(conf *config.Config) (cc *compiledConfig, err error) {
 		useRawDocstring:     conf.Processor.UseRawDocstring,
 		markers:             conf.Processor.CustomMarkers,
 		caseIgnoreAliases:   conf.Processor.CaseIgnoreAliases,
+		omitDeprecated:      conf.Processor.OmitDeprecated,
 	}
 
 	for i, t := range conf.Processor.IgnoreTypes {
@@ -55,6 +57,28 @@ func compileConfig(conf *config.Config) (cc *compiledConfig, err error) {
 		}
 	}
 
+	cc.featureGateMarker = config.DefaultFeatureGateMarker
+	if mc := conf.Processor.Maturity; mc != nil {
+		if mc.FeatureGateMarker != "" {
+			cc.featureGateMarker = mc.FeatureGateMarker
+		}
+		cc.minMaturity = mc.MinLevel
+	}
+
+	if kt := conf.Processor.KubeTypes; kt != nil && kt.Include {
+		packages := kt.Packages
+		if len(packages) == 0 {
+			packages = []string{config.KubePackagesRegex}
+		}
+
+		cc.kubePackages = make([]*regexp.Regexp, len(packages))
+		for i, pkg := range packages {
+			if cc.kubePackages[i], err = regexp.Compile(pkg); err != nil {
+				return nil, fmt.Errorf("failed to compile Kubernetes package regex '%s': %w", pkg, err)
+			}
+		}
+	}
+
 	return
 }
 
@@ -65,6 +89,10 @@ type compiledConfig struct {
 	useRawDocstring     bool
 	markers             []config.Marker
 	caseIgnoreAliases   []config.NamingConvention
+	kubePackages        []*regexp.Regexp
+	featureGateMarker   string
+	minMaturity         types.Maturity
+	omitDeprecated      bool
 }
 
 func (cc *compiledConfig) shouldIgnoreGroupVersion(gv string) bool {
@@ -113,3 +141,18 @@ func (cc *compiledConfig) shouldIgnoreField(typeName, fieldName string) bool {
 
 	return false
 }
+
+// shouldIncludeKubeType reports whether the Kubernetes types of the package pkg are documented.
+func (cc *compiledConfig) shouldIncludeKubeType(pkg string) bool {
+	if cc == nil {
+		return false
+	}
+
+	for _, re := range cc.kubePackages {
+		if re.MatchString(pkg) {
+			return true
+		}
+	}
+
+	return false
+}
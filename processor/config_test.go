@@ -34,3 +34,23 @@ func TestCompiledConfig(t *testing.T) {
 		require.False(t, cc.shouldIgnoreGroupVersion("groupz/v1beta1"))
 	})
 }
+
+func TestCompiledConfigKubeTypes(t *testing.T) {
+	cc, err := compileConfig(&config.Config{})
+	require.NoError(t, err)
+	require.False(t, cc.shouldIncludeKubeType("k8s.io/api/core/v1"))
+
+	cc, err = compileConfig(&config.Config{Processor: config.ProcessorConfig{KubeTypes: &config.KubeTypesConfig{Include: true}}})
+	require.NoError(t, err)
+	require.True(t, cc.shouldIncludeKubeType("k8s.io/api/core/v1"))
+	require.True(t, cc.shouldIncludeKubeType("k8s.io/apimachinery/pkg/apis/meta/v1"))
+	require.False(t, cc.shouldIncludeKubeType("sigs.k8s.io/gateway-api/apis/v1beta1"))
+
+	cc, err = compileConfig(&config.Config{Processor: config.ProcessorConfig{KubeTypes: &config.KubeTypesConfig{
+		Include:  true,
+		Packages: []string{`^k8s\.io/api/core/v1$`},
+	}}})
+	require.NoError(t, err)
+	require.True(t, cc.shouldIncludeKubeType("k8s.io/api/core/v1"))
+	require.False(t, cc.shouldIncludeKubeType("k8s.io/apimachinery/pkg/apis/meta/v1"))
+}
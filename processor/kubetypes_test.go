@@ -0,0 +1,48 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectKubeTypes(t *testing.T) {
+	str := &types.Type{Name: "string", Kind: types.BasicKind}
+	requirement := &types.Type{UID: "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement", Name: "LabelSelectorRequirement", Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Kind: types.StructKind,
+		Fields: types.Fields{{Name: "key", Type: str}}}
+	selector := &types.Type{UID: "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", Name: "LabelSelector", Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Kind: types.StructKind,
+		Fields: types.Fields{{Name: "matchExpressions", Type: &types.Type{Name: "LabelSelectorRequirement", Package: requirement.Package, Kind: types.SliceKind, UnderlyingType: requirement}}}}
+	resources := &types.Type{UID: "k8s.io/api/core/v1.ResourceRequirements", Name: "ResourceRequirements", Package: "k8s.io/api/core/v1", Kind: types.StructKind}
+	spec := &types.Type{UID: "example.com/api/v1.Spec", Name: "Spec", Package: "example.com/api/v1", Kind: types.StructKind,
+		Fields: types.Fields{
+			{Name: "selector", Type: &types.Type{Name: "LabelSelector", Package: selector.Package, Kind: types.PointerKind, UnderlyingType: selector}},
+			{Name: "resources", Type: resources},
+			{Name: "name", Type: str},
+		}}
+
+	cc, err := compileConfig(&config.Config{Processor: config.ProcessorConfig{
+		IgnoreTypes: []string{"LabelSelectorRequirement$"},
+		KubeTypes:   &config.KubeTypesConfig{Include: true, Packages: []string{`^k8s\.io/apimachinery/`}},
+	}})
+	require.NoError(t, err)
+
+	p := &processor{compiledConfig: cc, types: types.TypeMap{}}
+	for _, typ := range []*types.Type{requirement, selector, resources, spec} {
+		p.types[typ.UID] = typ
+	}
+
+	require.Equal(t, types.TypeMap{
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector": selector,
+	}, p.collectKubeTypes(types.TypeMap{"Spec": spec}))
+
+	p.compiledConfig.ignoreTypes = nil
+	require.Equal(t, types.TypeMap{
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector":            selector,
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement": requirement,
+	}, p.collectKubeTypes(types.TypeMap{"Spec": spec}))
+
+	p.compiledConfig.kubePackages = nil
+	require.Nil(t, p.collectKubeTypes(types.TypeMap{"Spec": spec}))
+}
@@ -0,0 +1,193 @@
+package processor
+
+import (
+	"go/ast"
+	"testing"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/require"
+	crdmarkers "sigs.k8s.io/controller-tools/pkg/crd/markers"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+)
+
+func TestDocControlMarkers(t *testing.T) {
+	registry, err := mkRegistry(nil, "")
+	require.NoError(t, err)
+
+	for _, target := range []markers.TargetType{markers.DescribesPackage, markers.DescribesType, markers.DescribesField} {
+		def := registry.Lookup("+"+hideMarker, target)
+		require.NotNil(t, def)
+		v, err := def.Parse("+" + hideMarker)
+		require.NoError(t, err)
+		require.Equal(t, struct{}{}, v)
+
+		def = registry.Lookup("+"+titleMarker, target)
+		require.NotNil(t, def)
+		v, err = def.Parse(`+` + titleMarker + `="Email address"`)
+		require.NoError(t, err)
+		require.Equal(t, "Email address", v)
+	}
+
+	values := markers.MarkerValues{
+		titleMarker: {" Positive integer "},
+		groupMarker: {""},
+	}
+	require.Equal(t, "Positive integer", stringMarker(values, titleMarker))
+	require.Equal(t, "", stringMarker(values, groupMarker))
+	require.Equal(t, "", stringMarker(values, descriptionMarker))
+}
+
+func TestParseMergeSemantics(t *testing.T) {
+	require.Nil(t, parseMergeSemantics(markers.MarkerValues{"optional": {struct{}{}}}))
+
+	ms := parseMergeSemantics(markers.MarkerValues{
+		"listType":   {crdmarkers.ListType("map")},
+		"listMapKey": {crdmarkers.ListMapKey("containerPort"), crdmarkers.ListMapKey("protocol")},
+	})
+	require.Equal(t, &types.MergeSemantics{ListType: "map", ListMapKeys: []string{"containerPort", "protocol"}}, ms)
+
+	ms = parseMergeSemantics(markers.MarkerValues{
+		"mapType":    {crdmarkers.MapType("atomic")},
+		"structType": {crdmarkers.StructType("granular")},
+	})
+	require.Equal(t, &types.MergeSemantics{MapType: "atomic", StructType: "granular"}, ms)
+}
+
+func TestParseSchemaFlags(t *testing.T) {
+	str := &types.Type{Name: "string", Kind: types.BasicKind}
+	require.Equal(t, types.SchemaFlags{}, parseSchemaFlags(nil, str))
+
+	flags := parseSchemaFlags(markers.MarkerValues{
+		"nullable": {crdmarkers.Nullable{}},
+		"kubebuilder:pruning:PreserveUnknownFields": {crdmarkers.XPreserveUnknownFields{}},
+		"kubebuilder:validation:EmbeddedResource":   {crdmarkers.XEmbeddedResource{}},
+		crdmarkers.SchemalessName:                   {crdmarkers.Schemaless{}},
+	}, str)
+	require.Equal(t, types.SchemaFlags{Nullable: true, PreserveUnknownFields: true, EmbeddedResource: true, Schemaless: true}, flags)
+
+	require.Equal(t, types.SchemaFlags{FreeForm: true}, parseSchemaFlags(nil, &types.Type{Name: "interface{}", Kind: types.InterfaceKind}))
+}
+
+func TestEffectiveValidations(t *testing.T) {
+	maxLength := types.Validation{Kind: types.ValidationMaxLength, Value: crdmarkers.MaxLength(10)}
+	minItems := types.Validation{Kind: types.ValidationMinItems, Value: crdmarkers.MinItems(1)}
+
+	name := &types.Type{Name: "Name", Kind: types.AliasKind, UnderlyingType: &types.Type{Name: "string", Kind: types.BasicKind}}
+	alias := &types.Type{Name: "Alias", Kind: types.AliasKind, UnderlyingType: name}
+	names := &types.Type{Name: "Names", Kind: types.AliasKind, UnderlyingType: &types.Type{Kind: types.SliceKind, UnderlyingType: &types.Type{Kind: types.PointerKind, UnderlyingType: alias}}}
+
+	p := &processor{
+		maxDepth:           10,
+		ownTypeValidations: map[*types.Type][]types.Validation{name: {maxLength}, names: {minItems}},
+	}
+
+	require.Equal(t, []types.Validation{
+		{Kind: types.ValidationMinItems, Value: crdmarkers.MinItems(2)},
+		{Kind: types.ValidationMinItems, Value: crdmarkers.MinItems(1), From: "Names"},
+		{Kind: types.ValidationMaxLength, Value: crdmarkers.MaxLength(10), Items: true, From: "Name"},
+	}, p.effectiveValidations([]types.Validation{{Kind: types.ValidationMinItems, Value: crdmarkers.MinItems(2)}}, names))
+
+	require.Equal(t, []types.Validation{
+		{Kind: types.ValidationMaxLength, Value: crdmarkers.MaxLength(10), From: "Name"},
+	}, p.effectiveValidations(nil, alias))
+
+	require.Equal(t, []types.Validation{maxLength}, p.effectiveValidations([]types.Validation{maxLength}, name))
+
+	require.Equal(t, []types.Validation{}, p.effectiveValidations(nil, nil))
+}
+
+func TestParseExample(t *testing.T) {
+	docs := &ast.CommentGroup{List: []*ast.Comment{
+		{Text: "// Headers of the page."},
+		{Text: "// +" + exampleMarker},
+		{Text: "// - title: Welcome"},
+		{Text: "//   size: 2"},
+		{Text: "//"},
+		{Text: "// More details."},
+	}}
+	require.Equal(t, "- title: Welcome\n  size: 2", exampleBlock(docs))
+	require.Equal(t, "", exampleBlock(nil))
+
+	example, doc := parseExample(markers.MarkerValues{exampleMarker: {struct{}{}}}, docs,
+		"Headers of the page.\n- title: Welcome\n  size: 2\n\nMore details.")
+	require.Equal(t, []any{yaml.MapSlice{{Key: "title", Value: "Welcome"}, {Key: "size", Value: uint64(2)}}}, example)
+	require.Equal(t, "Headers of the page.\n\nMore details.", doc)
+
+	example, doc = parseExample(markers.MarkerValues{kubebuilderExampleMarker: {crdmarkers.Example{Value: 3}}}, nil, "Page.")
+	require.Equal(t, 3, example)
+	require.Equal(t, "Page.", doc)
+
+	example, _ = parseExample(markers.MarkerValues{}, nil, "")
+	require.Nil(t, example)
+}
+
+func TestParseMaturity(t *testing.T) {
+	p := &processor{compiledConfig: &compiledConfig{featureGateMarker: "featureGate"}}
+
+	maturity, featureGate := p.parseMaturity(markers.MarkerValues{maturityMarker: {"Beta"}}, "Spec")
+	require.Equal(t, types.MaturityBeta, maturity)
+	require.Empty(t, featureGate)
+
+	// feature gates do not change the maturity
+	maturity, featureGate = p.parseMaturity(markers.MarkerValues{"featureGate": {"Foo"}}, "Spec.digest")
+	require.Equal(t, types.MaturityStable, maturity)
+	require.Equal(t, "Foo", featureGate)
+
+	maturity, _ = p.parseMaturity(markers.MarkerValues{"featureGate": {"Foo"}, maturityMarker: {"alpha"}}, "Spec.digest")
+	require.Equal(t, types.MaturityAlpha, maturity)
+
+	maturity, _ = p.parseMaturity(markers.MarkerValues{maturityMarker: {"ga"}}, "Spec")
+	require.Equal(t, types.MaturityStable, maturity)
+}
+
+func TestCustomMarkerCollision(t *testing.T) {
+	registry, err := mkRegistry([]config.Marker{
+		{Name: "featureGate", Target: config.TargetTypeField},
+		{Name: "hidefromdoc", Target: config.TargetTypeField},
+	}, "featureGate")
+	require.NoError(t, err)
+
+	def := registry.Lookup("+featureGate", markers.DescribesField)
+	require.NotNil(t, def)
+	v, err := def.Parse("+featureGate=Foo")
+	require.NoError(t, err)
+	require.Equal(t, "Foo", v)
+
+	def = registry.Lookup("+hidefromdoc", markers.DescribesField)
+	require.NotNil(t, def)
+	v, err = def.Parse("+hidefromdoc")
+	require.NoError(t, err)
+	require.Equal(t, struct{}{}, v)
+}
+
+func TestParseDeprecation(t *testing.T) {
+	registry, err := mkRegistry(nil, "")
+	require.NoError(t, err)
+
+	def := registry.Lookup("+"+deprecatedMarker, markers.DescribesField)
+	require.NotNil(t, def)
+	v, err := def.Parse("+" + deprecatedMarker)
+	require.NoError(t, err)
+	require.Equal(t, deprecation{}, v)
+	v, err = def.Parse(`+` + deprecatedMarker + `:message="No longer used.",replacement=rating`)
+	require.NoError(t, err)
+	require.Equal(t, deprecation{Message: "No longer used.", Replacement: "rating"}, v)
+
+	d, doc := parseDeprecation(markers.MarkerValues{deprecatedMarker: {deprecation{Replacement: "rating"}}}, "Score.\n\nDeprecated: no longer used.")
+	require.Equal(t, &types.Deprecation{Message: "no longer used.", Replacement: "rating"}, d)
+	require.Equal(t, "Score.", doc)
+
+	d, doc = parseDeprecation(markers.MarkerValues{}, "Score.")
+	require.Nil(t, d)
+	require.Equal(t, "Score.", doc)
+}
+
+func TestCELRules(t *testing.T) {
+	require.Equal(t, []types.CELRule{{Rule: "self == oldSelf", Message: "immutable"}}, celRules([]types.Validation{
+		{Kind: types.ValidationXValidation, Value: crdmarkers.XValidation{Rule: "self == oldSelf", Message: "immutable"}},
+		{Kind: types.ValidationXValidation, Value: crdmarkers.XValidation{Rule: "self != ''"}, Items: true},
+		{Kind: types.ValidationMaxLength, Value: crdmarkers.MaxLength(10)},
+	}))
+}
@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/elastic/crd-ref-docs/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+	"sigs.k8s.io/controller-tools/pkg/loader"
+)
+
+func TestModuleOf(t *testing.T) {
+	testCases := []struct {
+		name     string
+		module   *packages.Module
+		expected *types.Module
+	}{
+		{
+			name:     "published module",
+			module:   &packages.Module{Path: "sigs.k8s.io/gateway-api", Version: "v0.7.1"},
+			expected: &types.Module{Path: "sigs.k8s.io/gateway-api", Version: "v0.7.1"},
+		},
+		{
+			name:     "replaced version",
+			module:   &packages.Module{Path: "sigs.k8s.io/gateway-api", Version: "v0.7.1", Replace: &packages.Module{Path: "sigs.k8s.io/gateway-api", Version: "v0.8.0"}},
+			expected: &types.Module{Path: "sigs.k8s.io/gateway-api", Version: "v0.8.0"},
+		},
+		{
+			name:   "local replacement",
+			module: &packages.Module{Path: "sigs.k8s.io/gateway-api", Version: "v0.7.1", Replace: &packages.Module{Path: "../gateway-api"}},
+		},
+		{
+			name:   "fork",
+			module: &packages.Module{Path: "sigs.k8s.io/gateway-api", Version: "v0.7.1", Replace: &packages.Module{Path: "example.com/gateway-api", Version: "v0.7.2"}},
+		},
+		{
+			name:   "main module",
+			module: &packages.Module{Path: "example.com/operator", Main: true},
+		},
+		{
+			name: "no module",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pkg := &loader.Package{Package: &packages.Package{Module: tc.module}}
+			require.Equal(t, tc.expected, moduleOf(pkg))
+		})
+	}
+}
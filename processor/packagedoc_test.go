@@ -0,0 +1,100 @@
+package processor
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+	"sigs.k8s.io/controller-tools/pkg/loader"
+)
+
+func mkPackage(t *testing.T, files map[string]string) *loader.Package {
+	t.Helper()
+
+	pkg := &loader.Package{Package: &packages.Package{}}
+	fset := token.NewFileSet()
+	for _, name := range []string{"doc.go", "groupversion_info.go", "types.go"} {
+		src, ok := files[name]
+		if !ok {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		require.NoError(t, err)
+		pkg.CompiledGoFiles = append(pkg.CompiledGoFiles, "/src/api/v1/"+name)
+		pkg.Syntax = append(pkg.Syntax, file)
+	}
+	return pkg
+}
+
+func TestExtractPkgDocumentation(t *testing.T) {
+	groupVersionInfo := `// Copyright 2024 Example Authors.
+
+// Package v1 contains the v1 API.
+//
+// Copyright of the sample data remains with its authors.
+//
+//	indented
+// +groupName=example.com
+package v1
+`
+	types := `// Package v1 holds the types.
+package v1
+`
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		raw   bool
+		want  string
+	}{
+		{
+			name:  "group name file",
+			files: map[string]string{"groupversion_info.go": groupVersionInfo, "types.go": types},
+			want:  "Package v1 contains the v1 API.\n\nCopyright of the sample data remains with its authors.\n\n\tindented",
+		},
+		{
+			name: "doc file",
+			files: map[string]string{
+				"doc.go":               "// Package v1 is documented here.\n//\n// TODO: remove\n// ---\n// Notes.\npackage v1\n",
+				"groupversion_info.go": groupVersionInfo,
+			},
+			want: "Package v1 is documented here.",
+		},
+		{
+			name: "raw doc file",
+			files: map[string]string{
+				"doc.go":               "// Package v1 is documented here.\n//\n// TODO: remove\n// ---\n// Notes.\npackage v1\n",
+				"groupversion_info.go": groupVersionInfo,
+			},
+			raw:  true,
+			want: "Package v1 is documented here.\n\nTODO: remove\n---\nNotes.",
+		},
+		{
+			name:  "all files",
+			files: map[string]string{"groupversion_info.go": "// +groupName=example.com\npackage v1\n", "types.go": types},
+			want:  "Package v1 holds the types.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &processor{compiledConfig: &compiledConfig{useRawDocstring: tt.raw}}
+			require.Equal(t, tt.want, p.extractPkgDocumentation(mkPackage(t, tt.files)))
+		})
+	}
+}
+
+func TestDeclaresGroupName(t *testing.T) {
+	parse := func(src string) *ast.File {
+		file, err := parser.ParseFile(token.NewFileSet(), "file.go", src, parser.ParseComments)
+		require.NoError(t, err)
+		return file
+	}
+
+	require.True(t, declaresGroupName(parse("// +groupName=example.com\n\n// Package v1.\npackage v1\n")))
+	require.False(t, declaresGroupName(parse("// Package v1.\npackage v1\n\n// +groupName=example.com\nvar x int\n")))
+	require.False(t, declaresGroupName(parse("/* +groupName=example.com */\npackage v1\n")))
+}
@@ -22,13 +22,15 @@ import (
 	"go/ast"
 	"go/token"
 	gotypes "go/types"
-	"regexp"
+	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"unicode"
 
 	"github.com/elastic/crd-ref-docs/config"
 	"github.com/elastic/crd-ref-docs/types"
+	"github.com/goccy/go-yaml"
 	"go.uber.org/zap"
 	"golang.org/x/tools/go/packages"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -40,14 +42,37 @@ import (
 
 const (
 	objectRootMarker = "kubebuilder:object:root"
+
+	// doc-control markers applicable to packages, types and fields
+	hideMarker        = "crd-ref-docs:hide"
+	titleMarker       = "crd-ref-docs:title"
+	groupMarker       = "crd-ref-docs:group"
+	descriptionMarker = "crd-ref-docs:description"
+
+	// example markers applicable to types and fields: +kubebuilder:example sets a single-line value, and
+	// +crd-ref-docs:example starts a YAML block made of the following comment lines, up to an empty line.
+	kubebuilderExampleMarker = "kubebuilder:example"
+	exampleMarker            = "crd-ref-docs:example"
+
+	// maturityMarker sets the maturity level of types and fields, e.g. +crd-ref-docs:maturity=alpha
+	maturityMarker = "crd-ref-docs:maturity"
+
+	// deprecatedMarker deprecates types and fields, e.g. +deprecated:message="No longer used",replacement=rating
+	deprecatedMarker = "deprecated"
 )
 
-var ignoredCommentRegex = regexp.MustCompile(`\s*^(?i:\+|copyright)`)
+// deprecation is the value of the +deprecated marker.
+type deprecation struct {
+	Message     string `marker:"message,optional"`
+	Replacement string `marker:"replacement,optional"`
+}
 
 type groupVersionInfo struct {
 	schema.GroupVersion
 	*loader.Package
 	doc     string
+	title   string
+	group   string
 	kinds   map[string]struct{}
 	types   types.TypeMap
 	markers markers.MarkerValues
@@ -69,8 +94,10 @@ func Process(config *config.Config) ([]types.GroupVersionDetails, error) {
 	}
 
 	p.types.InlineTypes(p.propagateReference)
+	p.collectOwnValidations()
 	p.types.PropagateMarkers()
 	p.parseMarkers()
+	p.types.ClassifyObservedState()
 
 	// collect references between types
 	for typeName, refs := range p.references {
@@ -86,14 +113,22 @@ func Process(config *config.Config) ([]types.GroupVersionDetails, error) {
 		}
 	}
 
+	excluded := make(map[string]struct{})
+	if p.minMaturity != "" {
+		for _, key := range p.types.ExcludeBelow(p.minMaturity) {
+			excluded[key] = struct{}{}
+		}
+	}
+	if p.omitDeprecated {
+		for _, key := range p.types.Exclude((*types.Type).Deprecated, (*types.Field).Deprecated) {
+			excluded[key] = struct{}{}
+		}
+	}
+
 	// build the return array
 	var gvDetails []types.GroupVersionDetails
 	for _, gvi := range p.groupVersions {
-		details := types.GroupVersionDetails{GroupVersion: gvi.GroupVersion, Doc: gvi.doc}
-		for k, _ := range gvi.kinds {
-			details.Kinds = append(details.Kinds, k)
-		}
-
+		details := types.GroupVersionDetails{GroupVersion: gvi.GroupVersion, Doc: gvi.doc, Title: gvi.title, DocGroup: gvi.group}
 		details.Types = make(types.TypeMap)
 		for name, t := range gvi.types {
 			key := types.Identifier(t)
@@ -102,13 +137,23 @@ func Process(config *config.Config) ([]types.GroupVersionDetails, error) {
 				zap.S().Debugw("Skipping excluded type", "type", name)
 				continue
 			}
+			if _, ok := excluded[key]; ok {
+				zap.S().Debugw("Skipping immature or deprecated type", "type", name)
+				continue
+			}
 			if typeDef, ok := p.types[key]; ok && typeDef != nil {
 				details.Types[name] = typeDef
 			} else {
 				zap.S().Fatalw("Type not loaded", "type", key)
 			}
 		}
+		for k, _ := range gvi.kinds {
+			if _, ok := details.Types[k]; ok || len(excluded) == 0 {
+				details.Kinds = append(details.Kinds, k)
+			}
+		}
 		details.Markers = gvi.markers
+		details.KubeTypes = p.collectKubeTypes(details.Types)
 		gvDetails = append(gvDetails, details)
 	}
 
@@ -129,7 +174,7 @@ func Process(config *config.Config) ([]types.GroupVersionDetails, error) {
 }
 
 func newProcessor(compiledConfig *compiledConfig, maxDepth int) (*processor, error) {
-	registry, err := mkRegistry(compiledConfig.markers)
+	registry, err := mkRegistry(compiledConfig.markers, compiledConfig.featureGateMarker)
 	if err != nil {
 		return nil, err
 	}
@@ -140,9 +185,10 @@ func newProcessor(compiledConfig *compiledConfig, maxDepth int) (*processor, err
 			Collector: &markers.Collector{Registry: registry},
 			Checker:   &loader.TypeChecker{},
 		},
-		groupVersions: make(map[schema.GroupVersion]*groupVersionInfo),
-		types:         make(types.TypeMap),
-		references:    make(map[string]map[string]struct{}),
+		groupVersions:  make(map[schema.GroupVersion]*groupVersionInfo),
+		types:          make(types.TypeMap),
+		references:     make(map[string]map[string]struct{}),
+		packageMarkers: make(map[string]markers.MarkerValues),
 	}
 
 	crd.AddKnownTypes(p.parser)
@@ -156,10 +202,17 @@ type processor struct {
 	groupVersions map[schema.GroupVersion]*groupVersionInfo
 	types         types.TypeMap
 	references    map[string]map[string]struct{}
+	// typeCount is the number of API types found so far, used to record their declaration order.
+	typeCount int
+	// ownTypeValidations and ownFieldValidations are the validation rules declared on each type and field.
+	ownTypeValidations  map[*types.Type][]types.Validation
+	ownFieldValidations map[*types.Field][]types.Validation
+	// packageMarkers are the package markers of the packages declaring types, keyed by package path.
+	packageMarkers map[string]markers.MarkerValues
 }
 
 func (p *processor) findAPITypes(directory string) error {
-	cfg := &packages.Config{Dir: directory}
+	cfg := &packages.Config{Dir: directory, Mode: packages.NeedModule}
 	pkgs, err := loader.LoadRootsWithConfig(cfg, "./...")
 	if err != nil {
 		return err
@@ -175,6 +228,11 @@ func (p *processor) findAPITypes(directory string) error {
 			continue
 		}
 
+		if gvInfo.markers.Get(hideMarker) != nil {
+			zap.S().Debugw("Skipping hidden package", "package", pkg.PkgPath)
+			continue
+		}
+
 		// let the parser know that we need this package
 		p.parser.AddPackage(pkg)
 
@@ -201,6 +259,12 @@ func (p *processor) findAPITypes(directory string) error {
 				return
 			}
 
+			// ignore types hidden with a marker
+			if info.Markers.Get(hideMarker) != nil {
+				zap.S().Debugw("Skipping hidden type", "type", info.Name)
+				return
+			}
+
 			// load the type
 			key := fmt.Sprintf("%s.%s", pkg.PkgPath, info.Name)
 			typeDef, ok := p.types[key]
@@ -209,6 +273,8 @@ func (p *processor) findAPITypes(directory string) error {
 			}
 
 			if typeDef != nil && typeDef.Kind != types.BasicKind {
+				p.typeCount++
+				typeDef.Order = p.typeCount
 				gvInfo.types[info.Name] = typeDef
 			}
 
@@ -234,13 +300,13 @@ func (p *processor) extractGroupVersionIfExists(collector *markers.Collector, pk
 		return nil
 	}
 
-	groupName := markerValues.Get("groupName")
+	groupName := markerValues.Get(types.GroupNameMarker)
 	if groupName == nil {
 		return nil
 	}
 
 	version := pkg.Name
-	if v := markerValues.Get("versionName"); v != nil {
+	if v := markerValues.Get(types.VersionNameMarker); v != nil {
 		version = v.(string)
 	}
 
@@ -251,30 +317,111 @@ func (p *processor) extractGroupVersionIfExists(collector *markers.Collector, pk
 		},
 		Package: pkg,
 		doc:     p.extractPkgDocumentation(pkg),
+		title:   stringMarker(markerValues, titleMarker),
+		group:   stringMarker(markerValues, groupMarker),
 		markers: markerValues,
 	}
+	if description := stringMarker(markerValues, descriptionMarker); description != "" {
+		gvInfo.doc = description
+	}
 
 	return gvInfo
 }
 
+// extractPkgDocumentation returns the doc comment of the doc.go file of pkg or, failing that, of the file carrying its
+// +groupName marker. The doc comments of all the files of pkg are used when neither has one.
 func (p *processor) extractPkgDocumentation(pkg *loader.Package) string {
-	var pkgComments []string
-
 	pkg.NeedSyntax()
-	for _, n := range pkg.Syntax {
-		if n.Doc == nil {
+	files := pkg.Syntax
+	if file := packageDocFile(pkg); file != nil {
+		files = []*ast.File{file}
+	}
+
+	var docs []string
+	for _, file := range files {
+		if doc := packageDoc(file.Doc, p.useRawDocstring); doc != "" {
+			docs = append(docs, doc)
+		}
+	}
+	return strings.Join(docs, "\n\n")
+}
+
+// packageDocFile returns the file of pkg holding its package documentation: doc.go, or the file declaring the
+// +groupName marker. It returns nil if no such file has a doc comment other than markers.
+func packageDocFile(pkg *loader.Package) *ast.File {
+	var groupFile *ast.File
+	for i, file := range pkg.Syntax {
+		if packageDoc(file.Doc, true) == "" {
 			continue
 		}
-		comment := n.Doc.Text()
-		commentLines := strings.Split(comment, "\n")
-		for _, line := range commentLines {
-			if !ignoredCommentRegex.MatchString(line) {
-				pkgComments = append(pkgComments, line)
+		if filepath.Base(pkg.CompiledGoFiles[i]) == "doc.go" {
+			return file
+		}
+		if groupFile == nil && declaresGroupName(file) {
+			groupFile = file
+		}
+	}
+	return groupFile
+}
+
+// declaresGroupName returns true if the +groupName marker is set in the comments preceding the package clause of file.
+func declaresGroupName(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if strings.HasPrefix(markerComment(comment), "+groupName=") {
+				return true
 			}
 		}
 	}
+	return false
+}
+
+// packageDoc returns the text of the package doc comment without its markers. As for types, the text is kept as-is
+// when raw is set, and lines starting with TODO and the lines following "---" are dropped otherwise.
+func packageDoc(docs *ast.CommentGroup, raw bool) string {
+	if docs == nil {
+		return ""
+	}
+
+	var group ast.CommentGroup
+	for _, comment := range docs.List {
+		if !strings.HasPrefix(markerComment(comment), "+") {
+			group.List = append(group.List, comment)
+		}
+	}
+	text := strings.TrimRight(group.Text(), "\n")
+	if raw {
+		return text
+	}
 
-	return strings.Join(pkgComments, "\n")
+	var lines []string
+	inCodeBlock := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inCodeBlock = !inCodeBlock
+		}
+		if !inCodeBlock {
+			if strings.HasPrefix(line, "TODO") {
+				continue
+			}
+			if strings.HasPrefix(line, "---") {
+				break
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// markerComment returns the trimmed text of a line comment, or an empty string for block comments.
+func markerComment(comment *ast.Comment) string {
+	if !strings.HasPrefix(comment.Text, "//") {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
 }
 
 func (p *processor) processType(pkg *loader.Package, parentType *types.Type, t gotypes.Type, depth int) *types.Type {
@@ -284,20 +431,18 @@ func (p *processor) processType(pkg *loader.Package, parentType *types.Type, t g
 		zap.S().Debugw("Skipping excluded type", "type", typeID)
 		return nil
 	}
+	if !rawType && p.isHiddenType(pkg, t) {
+		zap.S().Debugw("Skipping hidden type", "type", typeID)
+		return nil
+	}
 
 	if processed, ok := p.types[typeDef.UID]; ok {
 		return processed
 	}
 
-	info := p.parser.LookupType(pkg, typeDef.Name)
-	if info != nil {
-		typeDef.Doc = info.Doc
-		typeDef.Markers = info.Markers
-
-		if p.useRawDocstring && info.RawDecl != nil {
-			// use raw docstring to support multi-line and indent preservation
-			typeDef.Doc = strings.TrimSuffix(info.RawDecl.Doc.Text(), "\n")
-		}
+	// imported types are looked up once their package is loaded
+	if typeDef.Package == pkg.PkgPath {
+		p.setTypeInfo(typeDef, p.parser.LookupType(pkg, typeDef.Name))
 	}
 
 	if depth > p.maxDepth {
@@ -324,7 +469,10 @@ func (p *processor) processType(pkg *loader.Package, parentType *types.Type, t g
 			}
 			p.parser.NeedPackage(importPkg)
 			pkg = importPkg
+			typeDef.Module = moduleOf(pkg)
+			p.setTypeInfo(typeDef, p.parser.LookupType(pkg, typeDef.Name))
 		}
+		typeDef.APIGroup = p.apiGroup(pkg)
 
 		typeDef.Kind = types.AliasKind
 		underlying := t.Underlying()
@@ -360,13 +508,21 @@ func (p *processor) processType(pkg *loader.Package, parentType *types.Type, t g
 		typeDef.UnderlyingType = p.processType(pkg, typeDef, t.Elem(), depth+1)
 		if typeDef.UnderlyingType != nil {
 			typeDef.Package = typeDef.UnderlyingType.Package
+			typeDef.Module = typeDef.UnderlyingType.Module
+			typeDef.APIGroup = typeDef.UnderlyingType.APIGroup
+			typeDef.Title = typeDef.UnderlyingType.Title
 		}
 
 	case *gotypes.Slice:
 		typeDef.Kind = types.SliceKind
+		// the example looked up by name is the example of an element
+		typeDef.Example = nil
 		typeDef.UnderlyingType = p.processType(pkg, typeDef, t.Elem(), depth+1)
 		if typeDef.UnderlyingType != nil {
 			typeDef.Package = typeDef.UnderlyingType.Package
+			typeDef.Module = typeDef.UnderlyingType.Module
+			typeDef.APIGroup = typeDef.UnderlyingType.APIGroup
+			typeDef.Title = typeDef.UnderlyingType.Title
 		}
 
 	case *gotypes.Map:
@@ -375,6 +531,8 @@ func (p *processor) processType(pkg *loader.Package, parentType *types.Type, t g
 		typeDef.ValueType = p.processType(pkg, typeDef, t.Elem(), depth+1)
 		if typeDef.ValueType != nil {
 			typeDef.Package = typeDef.ValueType.Package
+			typeDef.Module = typeDef.ValueType.Module
+			typeDef.APIGroup = typeDef.ValueType.APIGroup
 		}
 
 	case *gotypes.Basic:
@@ -392,20 +550,133 @@ func (p *processor) processType(pkg *loader.Package, parentType *types.Type, t g
 	return typeDef
 }
 
+// setTypeInfo sets the doc, markers and doc-control overrides of typeDef from its declaration.
+func (p *processor) setTypeInfo(typeDef *types.Type, info *markers.TypeInfo) {
+	if info == nil {
+		return
+	}
+
+	typeDef.Doc = info.Doc
+	typeDef.Markers = info.Markers
+
+	if p.useRawDocstring && info.RawDecl != nil {
+		// use raw docstring to support multi-line and indent preservation
+		typeDef.Doc = strings.TrimSuffix(info.RawDecl.Doc.Text(), "\n")
+	}
+
+	typeDef.Title = stringMarker(info.Markers, titleMarker)
+	typeDef.DocGroup = stringMarker(info.Markers, groupMarker)
+	if description := stringMarker(info.Markers, descriptionMarker); description != "" {
+		typeDef.Doc = description
+	}
+
+	docs := info.RawSpec.Doc
+	if docs == nil && info.RawDecl != nil {
+		docs = info.RawDecl.Doc
+	}
+	typeDef.Example, typeDef.Doc = parseExample(info.Markers, docs, typeDef.Doc)
+	typeDef.Deprecation, typeDef.Doc = parseDeprecation(info.Markers, typeDef.Doc)
+}
+
+// parseDeprecation returns the deprecation set with the +deprecated marker or described by the "Deprecated:" paragraph
+// of the doc, and the doc without that paragraph. The values of the marker take precedence.
+func parseDeprecation(values markers.MarkerValues, doc string) (*types.Deprecation, string) {
+	d, doc := types.ParseDeprecation(doc)
+	if marker, ok := values.Get(deprecatedMarker).(deprecation); ok {
+		if d == nil {
+			d = &types.Deprecation{}
+		}
+		if marker.Message != "" {
+			d.Message = marker.Message
+		}
+		if marker.Replacement != "" {
+			d.Replacement = marker.Replacement
+		}
+	}
+	return d, doc
+}
+
+// parseExample returns the example value set with the example markers, and the doc without the lines of the YAML
+// block of the +crd-ref-docs:example marker.
+func parseExample(values markers.MarkerValues, docs *ast.CommentGroup, doc string) (any, string) {
+	if values.Get(exampleMarker) != nil {
+		block := exampleBlock(docs)
+		doc = strings.Replace(doc, "+"+exampleMarker+"\n", "", 1)
+		if block != "" {
+			if i := strings.Index(doc, block); i >= 0 {
+				doc = doc[:i] + strings.TrimPrefix(doc[i+len(block):], "\n")
+			}
+		}
+		doc = strings.Trim(doc, "\n")
+
+		var example any
+		// decode objects as yaml.MapSlice to keep the order of their keys
+		if err := yaml.UnmarshalWithOptions([]byte(block), &example, yaml.UseOrderedMap()); err != nil {
+			zap.S().Warnw("Failed to parse example", "example", block, "error", err)
+			return nil, doc
+		}
+		return example, doc
+	}
+
+	if example, ok := values.Get(kubebuilderExampleMarker).(crdmarkers.Example); ok {
+		return example.Value, doc
+	}
+	return nil, doc
+}
+
+// exampleBlock returns the comment lines following the +crd-ref-docs:example marker, up to the first empty line or
+// marker.
+func exampleBlock(docs *ast.CommentGroup) string {
+	if docs == nil {
+		return ""
+	}
+
+	var lines []string
+	inBlock := false
+	for _, comment := range docs.List {
+		text := strings.TrimPrefix(strings.TrimPrefix(comment.Text, "//"), " ")
+		if strings.TrimSpace(text) == "+"+exampleMarker {
+			inBlock = true
+			continue
+		}
+		if !inBlock {
+			continue
+		}
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "+") {
+			break
+		}
+		lines = append(lines, text)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (p *processor) processStructFields(parentType *types.Type, pkg *loader.Package, info *markers.TypeInfo, depth int) {
 	logger := zap.S().With("package", pkg.PkgPath, "type", parentType.String())
 	logger.Debugw("Processing struct fields")
 	parentTypeKey := types.Identifier(parentType)
 
 	for _, f := range info.Fields {
+		if f.Markers.Get(hideMarker) != nil {
+			logger.Debugw("Skipping hidden field", "field", f.Name)
+			continue
+		}
+
 		fieldDef := &types.Field{
 			Name:     f.Name,
 			Markers:  f.Markers,
 			Doc:      f.Doc,
 			Embedded: f.Name == "",
+			Title:    stringMarker(f.Markers, titleMarker),
+			DocGroup: stringMarker(f.Markers, groupMarker),
+		}
+		if description := stringMarker(f.Markers, descriptionMarker); description != "" {
+			fieldDef.Doc = description
 		}
+		fieldDef.Example, fieldDef.Doc = parseExample(f.Markers, f.RawField.Doc, fieldDef.Doc)
+		fieldDef.Deprecation, fieldDef.Doc = parseDeprecation(f.Markers, fieldDef.Doc)
 
 		var caseIgnore bool
+		inline, omitEmpty := fieldDef.Embedded, false
 		if tagVal, ok := f.Tag.Lookup("json"); ok {
 			args := strings.Split(tagVal, ",")
 			if len(args) > 0 && args[0] != "" {
@@ -415,7 +686,10 @@ func (p *processor) processStructFields(parentType *types.Type, pkg *loader.Pack
 				fieldDef.Inlined = true
 			}
 			caseIgnore = hasCaseIgnore(args)
+			inline = args[0] == "" || slices.Contains(args[1:], "inline")
+			omitEmpty = slices.Contains(args[1:], "omitempty")
 		}
+		fieldDef.Required = types.RequiredField(f.Markers, inline, omitEmpty, p.optionalByDefault(pkg))
 
 		t := pkg.TypesInfo.TypeOf(f.RawField.Type)
 		if t == nil {
@@ -452,6 +726,65 @@ func (p *processor) processStructFields(parentType *types.Type, pkg *loader.Pack
 	}
 }
 
+// isHiddenType returns true if t, or the element type of t for pointers, slices and arrays, is a named type hidden with
+// the +crd-ref-docs:hide marker. Hidden types are then treated as ignored types by the fields using them.
+func (p *processor) isHiddenType(pkg *loader.Package, t gotypes.Type) bool {
+	for {
+		switch elem := t.(type) {
+		case *gotypes.Pointer:
+			t = elem.Elem()
+			continue
+		case *gotypes.Slice:
+			t = elem.Elem()
+			continue
+		case *gotypes.Array:
+			t = elem.Elem()
+			continue
+		}
+		break
+	}
+
+	named, ok := t.(*gotypes.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	if path := named.Obj().Pkg().Path(); path != pkg.PkgPath {
+		importPkg, ok := pkg.Imports()[path]
+		if !ok {
+			return false
+		}
+		p.parser.NeedPackage(importPkg)
+		pkg = importPkg
+	}
+	info := p.parser.LookupType(pkg, named.Obj().Name())
+	return info != nil && info.Markers.Get(hideMarker) != nil
+}
+
+// optionalByDefault returns true if the fields of pkg are optional unless marked as required, as set with the
+// +kubebuilder:validation:Optional package marker.
+func (p *processor) optionalByDefault(pkg *loader.Package) bool {
+	return types.GroupVersionDetails{Markers: p.markersOf(pkg)}.FieldsOptionalByDefault()
+}
+
+// apiGroup returns the API group of pkg, set with the +groupName package marker, if any.
+func (p *processor) apiGroup(pkg *loader.Package) string {
+	return stringMarker(p.markersOf(pkg), types.GroupNameMarker)
+}
+
+// markersOf returns the package markers of pkg.
+func (p *processor) markersOf(pkg *loader.Package) markers.MarkerValues {
+	markerValues, ok := p.packageMarkers[pkg.PkgPath]
+	if !ok {
+		var err error
+		markerValues, err = markers.PackageMarkers(p.parser.Collector, pkg)
+		if err != nil {
+			pkg.AddError(err)
+		}
+		p.packageMarkers[pkg.PkgPath] = markerValues
+	}
+	return markerValues
+}
+
 func mkType(pkg *loader.Package, t gotypes.Type) (*types.Type, bool) {
 	qualifier := gotypes.RelativeTo(pkg.Types)
 	cleanTypeName := strings.TrimLeft(gotypes.TypeString(t, qualifier), "*[]")
@@ -474,6 +807,83 @@ func mkType(pkg *loader.Package, t gotypes.Type) (*types.Type, bool) {
 	return typeDef, rawType
 }
 
+// collectKubeTypes returns the Kubernetes types selected by the configuration that are referenced, directly or through
+// other selected Kubernetes types, by the given types.
+func (p *processor) collectKubeTypes(typeMap types.TypeMap) types.TypeMap {
+	if len(p.kubePackages) == 0 {
+		return nil
+	}
+
+	kubeTypes := make(types.TypeMap)
+	var visit func(t *types.Type)
+	visit = func(t *types.Type) {
+		if t == nil {
+			return
+		}
+
+		switch t.Kind {
+		case types.PointerKind, types.SliceKind:
+			visit(t.UnderlyingType)
+			return
+		case types.MapKind:
+			visit(t.KeyType)
+			visit(t.ValueType)
+			return
+		case types.BasicKind:
+			return
+		}
+
+		key := types.Identifier(t)
+		if _, ok := kubeTypes[key]; ok || !p.shouldIncludeKubeType(t.Package) || p.shouldIgnoreType(key) {
+			return
+		}
+
+		// Use the fully processed type, which carries the fields and references.
+		if loaded, ok := p.types[t.UID]; ok {
+			t = loaded
+		}
+		kubeTypes[key] = t
+		visitKubeTypeChildren(t, visit)
+	}
+
+	for _, t := range typeMap {
+		visitKubeTypeChildren(t, visit)
+	}
+
+	if len(kubeTypes) == 0 {
+		return nil
+	}
+
+	return kubeTypes
+}
+
+func visitKubeTypeChildren(t *types.Type, visit func(t *types.Type)) {
+	visit(t.UnderlyingType)
+	for _, f := range t.Fields {
+		visit(f.Type)
+	}
+}
+
+// moduleOf returns the published module version providing pkg, if any. Packages of the main module, of modules
+// replaced by local directories and of modules replaced by a different module path have no published version.
+func moduleOf(pkg *loader.Package) *types.Module {
+	mod := pkg.Module
+	if mod == nil {
+		return nil
+	}
+	if mod.Replace != nil {
+		if mod.Replace.Path != mod.Path {
+			return nil
+		}
+		mod = mod.Replace
+	}
+	if mod.Main || mod.Version == "" {
+		return nil
+	}
+
+	return &types.Module{Path: mod.Path, Version: mod.Version}
+}
+
 // Every child that has a reference to 'originalType', will also get a reference to 'additionalType'.
 func (p *processor) propagateReference(originalType *types.Type, additionalType *types.Type) {
 	for _, parentRefs := range p.references {
@@ -502,7 +912,7 @@ func (p *processor) addReference(parent *types.Type, child *types.Type) {
 	}
 }
 
-func mkRegistry(customMarkers []config.Marker) (*markers.Registry, error) {
+func mkRegistry(customMarkers []config.Marker, featureGateMarker string) (*markers.Registry, error) {
 	registry := &markers.Registry{}
 	if err := registry.Define(objectRootMarker, markers.DescribesType, true); err != nil {
 		return nil, err
@@ -522,6 +932,42 @@ func mkRegistry(customMarkers []config.Marker) (*markers.Registry, error) {
 		return nil, err
 	}
 
+	if err := registry.Define(types.OrderMarker, markers.DescribesField, 0); err != nil {
+		return nil, err
+	}
+
+	// +kubebuilder:example only applies to fields in controller-tools
+	if err := registry.Register(markers.Must(markers.MakeAnyTypeDefinition(kubebuilderExampleMarker, markers.DescribesType, crdmarkers.Example{}))); err != nil {
+		return nil, err
+	}
+
+	for _, target := range []markers.TargetType{markers.DescribesPackage, markers.DescribesType, markers.DescribesField} {
+		if err := registry.Define(hideMarker, target, struct{}{}); err != nil {
+			return nil, err
+		}
+		if target != markers.DescribesPackage {
+			if err := registry.Define(exampleMarker, target, struct{}{}); err != nil {
+				return nil, err
+			}
+			if err := registry.Define(maturityMarker, target, ""); err != nil {
+				return nil, err
+			}
+			if err := registry.Define(deprecatedMarker, target, deprecation{}); err != nil {
+				return nil, err
+			}
+			if featureGateMarker != "" {
+				if err := registry.Define(featureGateMarker, target, ""); err != nil {
+					return nil, err
+				}
+			}
+		}
+		for _, name := range []string{titleMarker, groupMarker, descriptionMarker} {
+			if err := registry.Define(name, target, ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	for _, marker := range customMarkers {
 		t := markers.DescribesField
 		switch marker.Target {
@@ -535,6 +981,11 @@ func mkRegistry(customMarkers []config.Marker) (*markers.Registry, error) {
 			zap.S().Warnf("Skipping custom marker %s with unknown target type %s", marker.Name, marker.Target)
 			continue
 		}
+		// redefining a marker would replace the value it is parsed to, e.g. the name of a feature gate
+		if registry.Lookup("+"+marker.Name, t) != nil {
+			zap.S().Warnf("Skipping custom marker %s, which is already defined", marker.Name)
+			continue
+		}
 
 		if err := registry.Define(marker.Name, t, struct{}{}); err != nil {
 			return nil, fmt.Errorf("failed to define custom marker %s: %w", marker.Name, err)
@@ -544,9 +995,17 @@ func mkRegistry(customMarkers []config.Marker) (*markers.Registry, error) {
 	return registry, nil
 }
 
-func parseMarkers(markers markers.MarkerValues) (string, []string) {
-	defaultValue := ""
-	validation := []string{}
+// stringMarker returns the value of the string marker with the given name, or an empty string if it is not set.
+func stringMarker(values markers.MarkerValues, name string) string {
+	if v, ok := values.Get(name).(string); ok {
+		return strings.TrimSpace(v)
+	}
+	return ""
+}
+
+func parseMarkers(markers markers.MarkerValues) (any, []types.Validation) {
+	var defaultValue any
+	validation := []types.Validation{}
 
 	markerNames := make([]string, 0, len(markers))
 	for name := range markers {
@@ -557,54 +1016,235 @@ func parseMarkers(markers markers.MarkerValues) (string, []string) {
 	for _, name := range markerNames {
 		value := markers[name][len(markers[name])-1]
 
-		if strings.HasPrefix(name, "kubebuilder:validation:") {
-			name := strings.TrimPrefix(name, "kubebuilder:validation:")
-
-			switch name {
-			case "items:Pattern", "Pattern":
-				value = fmt.Sprintf("`%s`", value)
-			// FIXME: XValidation currently removed due to being long and difficult to read.
-			// E.g. "XValidation: {self.page < 200 Please start a new book.}"
-			case "XValidation":
-				continue
-			}
-			validation = append(validation, fmt.Sprintf("%s: %v", name, value))
+		if v, ok := parseValidation(name, value); ok {
+			validation = append(validation, v)
 		}
 
 		switch v := value.(type) {
 		case crdmarkers.KubernetesDefault:
-			defaultValue = fmt.Sprintf("%v", v.Value)
+			defaultValue = v.Value
 		case crdmarkers.Default:
-			defaultValue = fmt.Sprintf("%v", v.Value)
+			defaultValue = v.Value
 		}
+	}
 
-		// Handle standalone +required and +k8s:required marker
-		// This is equivalent to +kubebuilder:validation:Required
-		if name == "required" || name == "k8s:required" {
-			validation = append(validation, "Required: {}")
-		}
-		// Handle standalone +optional and +k8s:optional marker
-		// This is equivalent to +kubebuilder:validation:Optional
-		if name == "optional" || name == "k8s:optional" {
-			validation = append(validation, "Optional: {}")
-		}
+	return defaultValue, validation
+}
+
+// parseValidation returns the validation rule set by the marker with the given name and value, if any.
+func parseValidation(name string, value any) (types.Validation, bool) {
+	if rule, ok := strings.CutPrefix(name, "kubebuilder:validation:"); ok {
+		kind, items := types.ParseValidationKind(rule)
+		return types.Validation{Kind: kind, Value: value, Marker: name, Items: items}, true
 	}
 
-	if strings.HasPrefix(defaultValue, "map[") {
-		defaultValue = strings.TrimPrefix(defaultValue, "map[")
-		defaultValue = strings.TrimSuffix(defaultValue, "]")
-		defaultValue = fmt.Sprintf("{ %s }", defaultValue)
+	switch name {
+	// Handle standalone +required and +k8s:required marker
+	// This is equivalent to +kubebuilder:validation:Required
+	case "required", "k8s:required":
+		return types.Validation{Kind: types.ValidationRequired, Value: struct{}{}, Marker: name}, true
+	// Handle standalone +optional and +k8s:optional marker
+	// This is equivalent to +kubebuilder:validation:Optional
+	case "optional", "k8s:optional":
+		return types.Validation{Kind: types.ValidationOptional, Value: struct{}{}, Marker: name}, true
 	}
 
-	return defaultValue, validation
+	return types.Validation{}, false
+}
+
+// parseValidations returns the validation rules set by every value of the markers, sorted by marker name. Unlike
+// parseMarkers, repeated markers are all kept, so that contradictions can be reported.
+func parseValidations(markerValues markers.MarkerValues) []types.Validation {
+	names := make([]string, 0, len(markerValues))
+	for name := range markerValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var validations []types.Validation
+	for _, name := range names {
+		for _, value := range markerValues[name] {
+			if v, ok := parseValidation(name, value); ok {
+				validations = append(validations, v)
+			}
+		}
+	}
+	return validations
+}
+
+// collectOwnValidations records the validation rules declared on each type and field, before the markers of types
+// are propagated to their aliases and fields.
+func (p *processor) collectOwnValidations() {
+	p.ownTypeValidations = make(map[*types.Type][]types.Validation)
+	p.ownFieldValidations = make(map[*types.Field][]types.Validation)
+	for _, t := range p.types {
+		p.ownTypeValidations[t] = parseValidations(t.Markers)
+		for _, f := range t.Fields {
+			p.ownFieldValidations[f] = parseValidations(f.Markers)
+		}
+	}
 }
 
 func (p *processor) parseMarkers() {
 	for _, t := range p.types {
-		t.Default, t.Validation = parseMarkers(t.Markers)
+		var validation []types.Validation
+		t.DefaultValue, validation = parseMarkers(t.Markers)
+		t.Default = types.DefaultString(t.DefaultValue)
+		if t.DefaultValue != nil {
+			if err := types.CheckValue(t.DefaultValue, t); err != nil {
+				zap.S().Warnw("Default value does not match the type", "type", t.Name, "error", err)
+			}
+		}
+		t.Validation = types.ValidationStrings(validation)
+		if t.Example != nil {
+			if err := types.CheckValue(t.Example, t); err != nil {
+				zap.S().Warnw("Example does not match the type", "type", t.Name, "error", err)
+			}
+		}
+		t.Validations = p.effectiveValidations(p.ownTypeValidations[t], inheritedFrom(t))
+		t.SchemaFlags = parseSchemaFlags(t.Markers, t)
+		t.Maturity, t.FeatureGate = p.parseMaturity(t.Markers, t.Name)
 		for _, f := range t.Fields {
-			f.Default, f.Validation = parseMarkers(f.Markers)
+			f.DefaultValue, validation = parseMarkers(f.Markers)
+			f.Default = types.DefaultString(f.DefaultValue)
+			if f.DefaultValue != nil {
+				if err := types.CheckValue(f.DefaultValue, f.Type); err != nil {
+					zap.S().Warnw("Default value does not match the field type", "type", t.Name, "field", f.Name, "error", err)
+				}
+			}
+			f.Validation = types.ValidationStrings(validation)
+			if f.Example != nil {
+				if err := types.CheckValue(f.Example, f.Type); err != nil {
+					zap.S().Warnw("Example does not match the field type", "type", t.Name, "field", f.Name, "error", err)
+				}
+			}
+			f.Validations = p.effectiveValidations(p.ownFieldValidations[f], f.Type)
+			f.MergeSemantics = parseMergeSemantics(f.Markers)
+			f.Mutability = types.FieldMutability(f.Name, celRules(f.Validations), celRules(t.Validations))
+			f.SchemaFlags = parseSchemaFlags(f.Markers, f.Type)
+			f.Maturity, f.FeatureGate = p.parseMaturity(f.Markers, t.Name+"."+f.Name)
+		}
+	}
+}
+
+// parseMaturity returns the maturity level and the feature gate set with the markers.
+func (p *processor) parseMaturity(values markers.MarkerValues, name string) (types.Maturity, string) {
+	featureGate := ""
+	if p.featureGateMarker != "" {
+		featureGate = stringMarker(values, p.featureGateMarker)
+	}
+
+	maturity, err := types.ParseMaturity(stringMarker(values, maturityMarker))
+	if err != nil {
+		zap.S().Warnw("Ignoring maturity", "name", name, "error", err)
+		maturity = types.MaturityStable
+	}
+	return maturity, featureGate
+}
+
+// celRules returns the CEL rules of the validations applying to the value itself, rather than to its items.
+func celRules(validations []types.Validation) []types.CELRule {
+	var rules []types.CELRule
+	for _, v := range validations {
+		if x, ok := v.Value.(crdmarkers.XValidation); ok && !v.Items {
+			rules = append(rules, types.CELRule{Rule: x.Rule, Message: x.Message})
+		}
+	}
+	return rules
+}
+
+// inheritedFrom returns the type whose validation rules t inherits, if any.
+func inheritedFrom(t *types.Type) *types.Type {
+	switch t.Kind {
+	case types.AliasKind, types.PointerKind, types.SliceKind:
+		return t.UnderlyingType
+	default:
+		return nil
+	}
+}
+
+// effectiveValidations returns the direct validation rules followed by the rules inherited from t, walking the
+// chains of aliases and pointers, and the elements of slices for the rules applying to the items of a list. Inherited
+// rules record the type declaring them, and rules of the same kind with different values are flagged as conflicts.
+func (p *processor) effectiveValidations(direct []types.Validation, t *types.Type) []types.Validation {
+	effective := append([]types.Validation{}, direct...)
+
+	items := false
+	for depth := 0; t != nil && depth <= p.maxDepth; depth++ {
+		if t.Kind != types.PointerKind && t.Kind != types.SliceKind {
+			for _, v := range p.ownTypeValidations[t] {
+				if items {
+					if v.Items {
+						// the items of the items of a list cannot be described
+						continue
+					}
+					v.Items = true
+				}
+				v.From = t.Name
+				effective = append(effective, v)
+			}
 		}
+
+		switch t.Kind {
+		case types.AliasKind, types.PointerKind:
+			t = t.UnderlyingType
+		case types.SliceKind:
+			if items {
+				t = nil
+				break
+			}
+			items = true
+			t = t.UnderlyingType
+		default:
+			t = nil
+		}
+	}
+
+	return types.MarkConflicts(effective)
+}
+
+// parseMergeSemantics returns the merge semantics set with the listType, listMapKey, mapType and structType markers,
+// or nil if none is set.
+func parseMergeSemantics(markerValues markers.MarkerValues) *types.MergeSemantics {
+	var ms types.MergeSemantics
+	if v, ok := markerValues.Get("listType").(crdmarkers.ListType); ok {
+		ms.ListType = string(v)
+	}
+	for _, v := range markerValues["listMapKey"] {
+		if key, ok := v.(crdmarkers.ListMapKey); ok {
+			ms.ListMapKeys = append(ms.ListMapKeys, string(key))
+		}
+	}
+	if v, ok := markerValues.Get("mapType").(crdmarkers.MapType); ok {
+		ms.MapType = string(v)
+	}
+	if v, ok := markerValues.Get("structType").(crdmarkers.StructType); ok {
+		ms.StructType = string(v)
+	}
+
+	if ms.ListType == "" && len(ms.ListMapKeys) == 0 && ms.MapType == "" && ms.StructType == "" {
+		return nil
+	}
+	return &ms
+}
+
+// parseSchemaFlags returns the schema properties relaxing validation of a field or type of type t.
+func parseSchemaFlags(markerValues markers.MarkerValues, t *types.Type) types.SchemaFlags {
+	has := func(names ...string) bool {
+		for _, name := range names {
+			if markerValues.Get(name) != nil {
+				return true
+			}
+		}
+		return false
+	}
+
+	return types.SchemaFlags{
+		Nullable:              has("nullable"),
+		EmbeddedResource:      has("kubebuilder:validation:EmbeddedResource", "kubebuilder:validation:XEmbeddedResource"),
+		PreserveUnknownFields: has("kubebuilder:pruning:PreserveUnknownFields", "kubebuilder:validation:XPreserveUnknownFields"),
+		Schemaless:            has(crdmarkers.SchemalessName),
+		FreeForm:              t.IsFreeForm(),
 	}
 }
 
@@ -18,8 +18,10 @@ package renderer
 
 import (
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
+	"regexp"
 	"strings"
 	"text/template"
 
@@ -33,6 +35,9 @@ const (
 	asciidocAnchorPrefix = "{anchor_prefix}-"
 )
 
+// plainTextRegex matches the literal values that need neither escaping nor a passthrough.
+var plainTextRegex = regexp.MustCompile(`^[A-Za-z0-9 ._:/-]*$`)
+
 type AsciidoctorRenderer struct {
 	conf *config.Config
 	*Functions
@@ -47,42 +52,103 @@ func NewAsciidoctorRenderer(conf *config.Config) (*AsciidoctorRenderer, error) {
 }
 
 func (adr *AsciidoctorRenderer) Render(gvd []types.GroupVersionDetails) error {
-	funcMap := combinedFuncMap(funcMap{prefix: "asciidoc", funcs: adr.ToFuncMap()}, funcMap{funcs: sprig.TxtFuncMap()})
+	files, err := adr.renderFiles(gvd)
+	if err != nil {
+		return err
+	}
 
-	var tpls fs.FS
-	if adr.conf.TemplatesDir != "" {
-		tpls = os.DirFS(adr.conf.TemplatesDir)
-	} else {
-		sub, err := fs.Sub(templates.Root, "asciidoctor")
-		if err != nil {
-			return err
-		}
-		tpls = sub
+	if err := writeFiles(adr.conf, files); err != nil {
+		return err
 	}
 
-	tmpl, err := loadTemplate(tpls, funcMap)
+	return writeInventory(adr.conf, files, adr)
+}
+
+func (adr *AsciidoctorRenderer) RenderTo(w io.Writer, gvd []types.GroupVersionDetails) error {
+	files, err := adr.renderFiles(gvd)
 	if err != nil {
 		return err
 	}
 
-	return renderTemplate(tmpl, adr.conf, "asciidoc", gvd)
+	return writeOutput(w, adr.conf.OutputMode == config.OutputModeGroup, files)
+}
+
+func (adr *AsciidoctorRenderer) renderFiles(gvd []types.GroupVersionDetails) ([]outputFile, error) {
+	adr.indexTypes(gvd)
+	tmpl, err := adr.loadTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := executeTemplate(tmpl, adr.conf, "asciidoc", gvd)
+	if err != nil {
+		return nil, err
+	}
+
+	return files, checkLinks(adr.conf, asciidocLinkSyntax{}, files)
+}
+
+// documentedTypes returns the types of gvd rendered with a heading by the templates.
+func (adr *AsciidoctorRenderer) documentedTypes(gvd types.GroupVersionDetails) []*types.Type {
+	return adr.renderedTypes(gvd, adr.ShouldRenderType)
+}
+
+// anchor returns the ID of the section documenting t in file, as rendered by the templates, with the attributes
+// defined in file, such as anchor_prefix, substituted.
+func (adr *AsciidoctorRenderer) anchor(file outputFile, t *types.Type) string {
+	return substituteAsciidocAttributes(asciidocAttributes(file.content), adr.RenderAnchorID(adr.TypeID(t)))
+}
+
+func (adr *AsciidoctorRenderer) loadTemplate() (*template.Template, error) {
+	funcMap := combinedFuncMap(funcMap{prefix: "asciidoc", funcs: adr.ToFuncMap()}, funcMap{funcs: sprig.TxtFuncMap()})
+
+	defaults, err := fs.Sub(templates.Root, "asciidoctor")
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides fs.FS
+	if adr.conf.TemplatesDir != "" {
+		overrides = os.DirFS(adr.conf.TemplatesDir)
+	}
+
+	return loadTemplate(defaults, overrides, funcMap)
 }
 
 func (adr *AsciidoctorRenderer) ToFuncMap() template.FuncMap {
 	return template.FuncMap{
-		"GroupVersionID":     adr.GroupVersionID,
-		"RenderAnchorID":     adr.RenderAnchorID,
-		"RenderExternalLink": adr.RenderExternalLink,
-		"RenderGVLink":       adr.RenderGVLink,
-		"RenderLocalLink":    adr.RenderLocalLink,
-		"RenderType":         adr.RenderType,
-		"RenderTypeLink":     adr.RenderTypeLink,
-		"SafeID":             adr.SafeID,
-		"ShouldRenderType":   adr.ShouldRenderType,
-		"TypeID":             adr.TypeID,
-		"RenderFieldDoc":     adr.RenderFieldDoc,
-		"RenderValidation":   adr.RenderValidation,
-		"TemplateValue":      adr.TemplateValue,
+		"FieldNotes":            adr.FieldNotes,
+		"FieldOrder":            adr.FieldOrder,
+		"GroupVersionID":        adr.GroupVersionID,
+		"ExampleYAML":           adr.ExampleYAML,
+		"IsBlockValue":          adr.IsBlockValue,
+		"IsCollapsed":           adr.IsCollapsed,
+		"KubeTypes":             adr.KubeTypes,
+		"ObservedStateMode":     adr.ObservedStateMode,
+		"ObservedStateSections": adr.ObservedStateSections,
+		"PackageGroups":         types.GroupPackages,
+		"RenderAnchorID":        adr.RenderAnchorID,
+		"RenderDOTDiagram":      adr.DOTDiagram,
+		"RenderExternalLink":    adr.RenderExternalLink,
+		"RenderGVLink":          adr.RenderGVLink,
+		"RenderLocalLink":       adr.RenderLocalLink,
+		"RenderMermaidDiagram":  adr.MermaidDiagram,
+		"RenderType":            adr.RenderType,
+		"RenderTypeLink":        adr.RenderTypeLink,
+		"SafeID":                adr.SafeID,
+		"ShouldRenderField":     adr.ShouldRenderField,
+		"ShouldRenderType":      adr.ShouldRenderType,
+		"ShowTypeDiagrams":      adr.ShowTypeDiagrams,
+		"TypeID":                adr.TypeID,
+		"TypeOrder":             adr.TypeOrder,
+		"RenderDoc":             adr.RenderDoc,
+		"RenderDocHTML":         adr.DocToHTML,
+		"RenderFieldDoc":        adr.RenderFieldDoc,
+		"RenderValidation":      adr.RenderValidation,
+		"RenderValidations":     adr.RenderValidations,
+		"RenderDefaultValue":    adr.RenderDefaultValue,
+		"RenderExample":         adr.RenderExample,
+		"TemplateValue":         adr.TemplateValue,
 	}
 }
 
@@ -134,7 +200,7 @@ func (adr *AsciidoctorRenderer) RenderExternalLink(link, text string) string {
 }
 
 func (adr *AsciidoctorRenderer) RenderGVLink(gv types.GroupVersionDetails) string {
-	return adr.RenderLocalLink(asciidocAnchorPrefix, adr.GroupVersionID(gv), gv.GroupVersionString())
+	return adr.RenderLocalLink(asciidocAnchorPrefix, adr.GroupVersionID(gv), gv.DisplayName())
 }
 
 func (adr *AsciidoctorRenderer) RenderAnchorID(id string) string {
@@ -148,7 +214,24 @@ func (adr *AsciidoctorRenderer) TemplateValue(key string) string {
 	return adr.conf.TemplateKeyValues.AsMap()[key]
 }
 
+// RenderDoc renders the doc string of a type or group version.
+func (adr *AsciidoctorRenderer) RenderDoc(text string) string {
+	if adr.parseDocComments() {
+		return adr.DocToAsciidoc(text, adr.renderDocLink)
+	}
+
+	return text
+}
+
+func (adr *AsciidoctorRenderer) renderDocLink(t *types.Type, text string) string {
+	return adr.RenderLocalLink(asciidocAnchorPrefix, adr.TypeID(t), text)
+}
+
 func (adr *AsciidoctorRenderer) RenderFieldDoc(text string) string {
+	if adr.parseDocComments() {
+		return escapePipe(adr.DocToAsciidocCell(text, adr.renderDocLink))
+	}
+
 	// Escape the pipe character, which has special meaning for asciidoc as a way to format tables,
 	// so that including | in a comment does not result in wonky tables.
 	out := escapePipe(text)
@@ -173,6 +256,49 @@ func (adr *AsciidoctorRenderer) RenderValidation(text string) string {
 	return escapeCurlyBraces(renderedText)
 }
 
+// RenderValidations describes the validation rules in prose, e.g. "1 ≤ value ≤ 5", for a table cell. Literal values
+// with special characters are rendered as passthroughs, so that they need no escaping.
+func (adr *AsciidoctorRenderer) RenderValidations(validations []types.Validation) []string {
+	descriptions := describeValidations(validations, inlineCode)
+	for i, d := range descriptions {
+		descriptions[i] = escapePipe(d)
+	}
+	return descriptions
+}
+
+// RenderDefaultValue renders a default value for a table cell, as an inline JSON literal or, for values spanning
+// multiple lines, as a YAML listing block, which requires an AsciiDoc cell (see IsBlockValue).
+func (adr *AsciidoctorRenderer) RenderDefaultValue(value any) string {
+	return adr.renderValue(value)
+}
+
+// RenderExample renders the example value of a field for a table cell, like RenderDefaultValue.
+func (adr *AsciidoctorRenderer) RenderExample(value any) string {
+	return adr.renderValue(value)
+}
+
+func (adr *AsciidoctorRenderer) renderValue(value any) string {
+	text, block := formatValue(value)
+	if text == "" {
+		return ""
+	}
+	if block {
+		return "\n[source,yaml]\n----\n" + escapePipe(text) + "\n----\n"
+	}
+	return escapePipe(inlineCode(text))
+}
+
+// inlineCode renders text as inline code, as a passthrough unless it is plain text.
+func inlineCode(text string) string {
+	if plainTextRegex.MatchString(text) {
+		return "`" + text + "`"
+	}
+	if strings.Contains(text, "++") {
+		return "`" + escapeCurlyBraces(escapeFirstAsterixInEachPair(text)) + "`"
+	}
+	return "`++" + text + "++`"
+}
+
 // escapeFirstAsterixInEachPair escapes the first asterix in each pair of
 // asterixes in text. E.g. "*a*b*c*" -> "\*a*b\*c*" and "*a*b*" -> "\*a*b*".
 func escapeFirstAsterixInEachPair(text string) string {
@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elastic/crd-ref-docs/types"
+)
+
+// typeEdge is a reference from a field of one type to another type.
+type typeEdge struct {
+	from  *types.Type
+	to    *types.Type
+	label string
+}
+
+// typeGraph collects the types owned by root, i.e. the documented types that are reachable through its fields, and
+// the edges between them. The root is always the first returned type.
+func (f *Functions) typeGraph(root *types.Type) ([]*types.Type, []typeEdge) {
+	nodes := []*types.Type{root}
+	var edges []typeEdge
+
+	visited := map[string]bool{types.Identifier(root): true}
+	for i := 0; i < len(nodes); i++ {
+		t := nodes[i]
+		for _, field := range t.Members() {
+			target, cardinality := elementType(field.Type)
+			if target == nil || target.IsBasic() {
+				continue
+			}
+			if _, local := f.LinkForType(target); !local {
+				continue
+			}
+
+			label := field.Name
+			if cardinality != "" {
+				label = fmt.Sprintf("%s (%s)", field.Name, cardinality)
+			}
+			edges = append(edges, typeEdge{from: t, to: target, label: label})
+
+			if id := types.Identifier(target); !visited[id] {
+				visited[id] = true
+				nodes = append(nodes, target)
+			}
+		}
+	}
+
+	return nodes, edges
+}
+
+// elementType returns the named type a field refers to along with the cardinality of the reference, which is either
+// "slice", "map", "pointer" or empty for a direct reference.
+func elementType(t *types.Type) (*types.Type, string) {
+	if t == nil {
+		return nil, ""
+	}
+
+	switch t.Kind {
+	case types.SliceKind:
+		target, _ := elementType(t.UnderlyingType)
+		return target, "slice"
+	case types.MapKind:
+		target, _ := elementType(t.ValueType)
+		return target, "map"
+	case types.PointerKind:
+		target, _ := elementType(t.UnderlyingType)
+		return target, "pointer"
+	default:
+		return t, ""
+	}
+}
+
+// MermaidDiagram renders the types owned by t as a Mermaid flowchart.
+func (f *Functions) MermaidDiagram(t *types.Type) string {
+	nodes, edges := f.typeGraph(t)
+
+	nodeID := func(t *types.Type) string {
+		return strings.ReplaceAll(f.TypeID(t), "-", "_")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	for _, n := range nodes {
+		fmt.Fprintf(&sb, "  %s[\"%s\"]\n", nodeID(n), n.Name)
+	}
+	for _, e := range edges {
+		fmt.Fprintf(&sb, "  %s -->|\"%s\"| %s\n", nodeID(e.from), e.label, nodeID(e.to))
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// DOTDiagram renders the types owned by t as a Graphviz DOT digraph.
+func (f *Functions) DOTDiagram(t *types.Type) string {
+	nodes, edges := f.typeGraph(t)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %q {\n", t.Name)
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, n := range nodes {
+		fmt.Fprintf(&sb, "  %q [label=%q];\n", f.TypeID(n), n.Name)
+	}
+	for _, e := range edges {
+		fmt.Fprintf(&sb, "  %q -> %q [label=%q];\n", f.TypeID(e.from), f.TypeID(e.to), e.label)
+	}
+	sb.WriteString("}")
+
+	return sb.String()
+}
+
+// ShowTypeDiagrams reports whether type relationship diagrams should be included in the output.
+func (f *Functions) ShowTypeDiagrams() bool {
+	return f.conf.Render.TypeDiagrams
+}
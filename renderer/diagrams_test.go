@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"testing"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func testTypeGraph() *types.Type {
+	str := &types.Type{Name: "string", Kind: types.BasicKind}
+	entry := &types.Type{Name: "Entry", Package: "example.com/api/v1", Kind: types.StructKind}
+	spec := &types.Type{Name: "Spec", Package: "example.com/api/v1", Kind: types.StructKind}
+	meta := &types.Type{Name: "ObjectMeta", Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Kind: types.StructKind}
+
+	entry.Fields = types.Fields{
+		{Name: "name", Type: str},
+	}
+	spec.Fields = types.Fields{
+		{Name: "entries", Type: &types.Type{Name: "Entry", Package: entry.Package, Kind: types.SliceKind, UnderlyingType: entry}},
+		{Name: "labels", Type: &types.Type{Kind: types.MapKind, KeyType: str, ValueType: entry}},
+		{Name: "primary", Type: &types.Type{Name: "Entry", Package: entry.Package, Kind: types.PointerKind, UnderlyingType: entry}},
+	}
+
+	return &types.Type{
+		Name:    "Book",
+		Package: "example.com/api/v1",
+		Kind:    types.StructKind,
+		GVK:     &schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Book"},
+		Fields: types.Fields{
+			{Name: "metadata", Type: meta},
+			{Name: "spec", Type: spec},
+		},
+	}
+}
+
+func TestMermaidDiagram(t *testing.T) {
+	f, err := NewFunctions(&config.Config{})
+	require.NoError(t, err)
+
+	expected := `graph LR
+  example_com_api_v1_book["Book"]
+  example_com_api_v1_spec["Spec"]
+  example_com_api_v1_entry["Entry"]
+  example_com_api_v1_book -->|"spec"| example_com_api_v1_spec
+  example_com_api_v1_spec -->|"entries (slice)"| example_com_api_v1_entry
+  example_com_api_v1_spec -->|"labels (map)"| example_com_api_v1_entry
+  example_com_api_v1_spec -->|"primary (pointer)"| example_com_api_v1_entry`
+	require.Equal(t, expected, f.MermaidDiagram(testTypeGraph()))
+}
+
+func TestDOTDiagram(t *testing.T) {
+	f, err := NewFunctions(&config.Config{})
+	require.NoError(t, err)
+
+	expected := `digraph "Book" {
+  rankdir=LR;
+  node [shape=box];
+  "example-com-api-v1-book" [label="Book"];
+  "example-com-api-v1-spec" [label="Spec"];
+  "example-com-api-v1-entry" [label="Entry"];
+  "example-com-api-v1-book" -> "example-com-api-v1-spec" [label="spec"];
+  "example-com-api-v1-spec" -> "example-com-api-v1-entry" [label="entries (slice)"];
+  "example-com-api-v1-spec" -> "example-com-api-v1-entry" [label="labels (map)"];
+  "example-com-api-v1-spec" -> "example-com-api-v1-entry" [label="primary (pointer)"];
+}`
+	require.Equal(t, expected, f.DOTDiagram(testTypeGraph()))
+}
@@ -0,0 +1,366 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"fmt"
+	"go/doc/comment"
+	"strings"
+
+	"github.com/elastic/crd-ref-docs/types"
+)
+
+// docHeadingLevel is the Markdown heading level of headings in doc comments, which are nested below type headings.
+const docHeadingLevel = 5
+
+// typeIndex resolves the types referenced by doc links (e.g. [GuestbookSpec]) to the documented types.
+type typeIndex struct {
+	byName map[string]*types.Type
+	byID   map[string]*types.Type
+}
+
+// indexTypes records the documented types, so that doc links can be resolved while rendering.
+func (f *Functions) indexTypes(gvds []types.GroupVersionDetails) {
+	f.types = &typeIndex{
+		byName: make(map[string]*types.Type),
+		byID:   make(map[string]*types.Type),
+	}
+
+	for _, gvd := range gvds {
+		for _, t := range append(gvd.SortedTypes(), gvd.SortedKubeTypes()...) {
+			f.types.byID[types.Identifier(t)] = t
+			if _, ok := f.types.byName[t.Name]; !ok {
+				f.types.byName[t.Name] = t
+			}
+		}
+	}
+}
+
+func (idx *typeIndex) lookup(importPath, name string) *types.Type {
+	if idx == nil {
+		return nil
+	}
+
+	if importPath != "" {
+		return idx.byID[importPath+"."+name]
+	}
+
+	return idx.byName[name]
+}
+
+// parseDocComments reports whether doc strings should be parsed using the Go doc comment syntax.
+func (f *Functions) parseDocComments() bool {
+	return f != nil && f.conf != nil && f.conf.Render.ParseDocComments
+}
+
+// parseDoc parses a doc string using the Go doc comment syntax.
+func (f *Functions) parseDoc(text string) *comment.Doc {
+	p := &comment.Parser{
+		LookupSym: func(recv, name string) bool {
+			return recv == "" && f.types.lookup("", name) != nil
+		},
+	}
+
+	doc := p.Parse(text)
+	f.rewriteDocLinks(doc)
+	return doc
+}
+
+// rewriteDocLinks applies the configured link mappings to the URLs found in doc.
+func (f *Functions) rewriteDocLinks(doc *comment.Doc) {
+	if f.conf == nil || len(f.conf.Render.LinkMappings) == 0 {
+		return
+	}
+
+	mappings := make(map[string]*comment.Link)
+	for _, lm := range f.conf.Render.LinkMappings {
+		mappings[lm.URL] = &comment.Link{Text: []comment.Text{comment.Plain(lm.Text)}, URL: lm.Link}
+	}
+
+	walkDocText(doc, func(text []comment.Text) {
+		for i, t := range text {
+			if l, ok := t.(*comment.Link); ok && l.Auto {
+				if mapped, ok := mappings[l.URL]; ok {
+					text[i] = mapped
+				}
+			}
+		}
+	})
+}
+
+// walkDocText calls fn with every sequence of inline text in doc.
+func walkDocText(doc *comment.Doc, fn func([]comment.Text)) {
+	var walk func(blocks []comment.Block)
+	walk = func(blocks []comment.Block) {
+		for _, b := range blocks {
+			switch b := b.(type) {
+			case *comment.Paragraph:
+				fn(b.Text)
+			case *comment.Heading:
+				fn(b.Text)
+			case *comment.List:
+				for _, item := range b.Items {
+					walk(item.Content)
+				}
+			}
+		}
+	}
+	walk(doc.Content)
+}
+
+// docLinkType returns the documented type a doc link refers to, if any.
+func (f *Functions) docLinkType(link *comment.DocLink) *types.Type {
+	if link.Recv != "" {
+		return nil
+	}
+	return f.types.lookup(link.ImportPath, link.Name)
+}
+
+// DocToMarkdown renders a doc string written using the Go doc comment syntax as Markdown.
+func (f *Functions) DocToMarkdown(text string, localLinkURL func(t *types.Type) string) string {
+	doc := f.parseDoc(text)
+	p := &comment.Printer{
+		HeadingLevel: docHeadingLevel,
+		HeadingID:    func(*comment.Heading) string { return "" },
+		DocLinkURL:   f.docLinkURL(localLinkURL),
+	}
+
+	return strings.TrimSpace(string(p.Markdown(doc)))
+}
+
+// DocToMarkdownCell renders a doc string written using the Go doc comment syntax as Markdown for a table cell, where
+// headings are reduced to paragraphs and code blocks to inline code.
+func (f *Functions) DocToMarkdownCell(text string, localLinkURL func(t *types.Type) string) string {
+	doc := f.parseDoc(text)
+	p := &comment.Printer{DocLinkURL: f.docLinkURL(localLinkURL)}
+
+	var parts []string
+	for _, b := range cellBlocks(doc.Content) {
+		if code, ok := b.(*comment.Code); ok {
+			parts = append(parts, markdownInlineCode(code.Text))
+			continue
+		}
+		parts = append(parts, strings.TrimRight(string(p.Markdown(&comment.Doc{Content: []comment.Block{b}})), "\n"))
+	}
+	// link definitions that are not used are printed at the end, like DocToMarkdown does
+	if links := strings.TrimSpace(string(p.Markdown(&comment.Doc{Links: doc.Links}))); links != "" {
+		parts = append(parts, links)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// cellBlocks returns the blocks with headings replaced by paragraphs, which can be rendered in table cells.
+func cellBlocks(blocks []comment.Block) []comment.Block {
+	out := make([]comment.Block, len(blocks))
+	for i, b := range blocks {
+		if h, ok := b.(*comment.Heading); ok {
+			b = &comment.Paragraph{Text: h.Text}
+		}
+		out[i] = b
+	}
+	return out
+}
+
+// markdownInlineCode renders each line of a code block as inline code, on separate lines.
+func markdownInlineCode(code string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(code, "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.Contains(line, "`") {
+			lines = append(lines, "`` "+line+" ``")
+		} else {
+			lines = append(lines, "`"+line+"`")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DocToHTML renders a doc string written using the Go doc comment syntax as HTML.
+func (f *Functions) DocToHTML(text string) string {
+	doc := f.parseDoc(text)
+	p := &comment.Printer{
+		HeadingLevel: docHeadingLevel,
+		HeadingID:    func(*comment.Heading) string { return "" },
+		DocLinkURL: f.docLinkURL(func(t *types.Type) string {
+			return "#" + f.TypeID(t)
+		}),
+	}
+
+	return strings.TrimSpace(string(p.HTML(doc)))
+}
+
+func (f *Functions) docLinkURL(localLinkURL func(t *types.Type) string) func(link *comment.DocLink) string {
+	return func(link *comment.DocLink) string {
+		t := f.docLinkType(link)
+		if t == nil {
+			return link.DefaultURL("https://pkg.go.dev")
+		}
+
+		url, local := f.LinkForType(t)
+		if local {
+			return localLinkURL(t)
+		}
+		return url
+	}
+}
+
+// DocToAsciidoc renders a doc string written using the Go doc comment syntax as AsciiDoc.
+func (f *Functions) DocToAsciidoc(text string, localLink func(t *types.Type, text string) string) string {
+	return f.docToAsciidoc(text, localLink, false)
+}
+
+// DocToAsciidocCell renders a doc string written using the Go doc comment syntax as AsciiDoc for a table cell, which
+// only supports paragraphs: headings are reduced to paragraphs, and code blocks and lists to lines of inline text.
+func (f *Functions) DocToAsciidocCell(text string, localLink func(t *types.Type, text string) string) string {
+	return f.docToAsciidoc(text, localLink, true)
+}
+
+func (f *Functions) docToAsciidoc(text string, localLink func(t *types.Type, text string) string, cell bool) string {
+	doc := f.parseDoc(text)
+	p := &asciidocPrinter{Functions: f, localLink: localLink, cell: cell}
+
+	var sb strings.Builder
+	p.blocks(&sb, doc.Content)
+	return strings.TrimSpace(sb.String())
+}
+
+// asciidocPrinter renders parsed doc comments as AsciiDoc, which go/doc/comment does not support natively.
+type asciidocPrinter struct {
+	*Functions
+	localLink func(t *types.Type, text string) string
+	// cell is set when rendering in a table cell, where blocks other than paragraphs are reduced to inline text.
+	cell bool
+}
+
+func (p *asciidocPrinter) blocks(sb *strings.Builder, blocks []comment.Block) {
+	if p.cell {
+		blocks = cellBlocks(blocks)
+	}
+
+	for _, b := range blocks {
+		switch b := b.(type) {
+		case *comment.Paragraph:
+			p.text(sb, b.Text)
+			sb.WriteString("\n\n")
+		case *comment.Heading:
+			sb.WriteString("[discrete]\n")
+			sb.WriteString(strings.Repeat("=", docHeadingLevel) + " ")
+			p.text(sb, b.Text)
+			sb.WriteString("\n\n")
+		case *comment.Code:
+			if p.cell {
+				sb.WriteString(asciidocInlineCode(b.Text))
+				sb.WriteString("\n\n")
+				continue
+			}
+			sb.WriteString("----\n")
+			sb.WriteString(b.Text)
+			sb.WriteString("----\n\n")
+		case *comment.List:
+			p.list(sb, b)
+		}
+	}
+}
+
+// list renders the items of a list, along with all their blocks. In table cells, the items are rendered as lines
+// separated by hard line breaks.
+func (p *asciidocPrinter) list(sb *strings.Builder, list *comment.List) {
+	marker, continuation, separator := "*", "\n+\n", "\n"
+	if list.Items[0].Number != "" {
+		marker = "."
+	}
+	if p.cell {
+		marker, continuation, separator = "-", " +\n", " +\n"
+	}
+
+	for i, item := range list.Items {
+		if i > 0 {
+			sb.WriteString(separator)
+		}
+		if p.cell && item.Number != "" {
+			sb.WriteString(item.Number + ". ")
+		} else {
+			sb.WriteString(marker + " ")
+		}
+		for j, c := range item.Content {
+			if j > 0 {
+				sb.WriteString(continuation)
+			}
+			var content strings.Builder
+			p.blocks(&content, []comment.Block{c})
+			sb.WriteString(strings.TrimSpace(content.String()))
+		}
+	}
+	sb.WriteString("\n\n")
+}
+
+// asciidocInlineCode renders each line of a code block as inline code, separated by hard line breaks.
+func asciidocInlineCode(code string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(code, "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, inlineCode(line))
+		}
+	}
+	return strings.Join(lines, " +\n")
+}
+
+func (p *asciidocPrinter) text(sb *strings.Builder, text []comment.Text) {
+	for _, t := range text {
+		switch t := t.(type) {
+		case comment.Plain:
+			sb.WriteString(strings.ReplaceAll(string(t), "\n", " "))
+		case comment.Italic:
+			fmt.Fprintf(sb, "_%s_", t)
+		case *comment.Link:
+			if t.Auto {
+				sb.WriteString(t.URL)
+				continue
+			}
+			fmt.Fprintf(sb, "link:%s[%s]", t.URL, p.plainText(t.Text))
+		case *comment.DocLink:
+			linkText := p.plainText(t.Text)
+			target := p.docLinkType(t)
+			if target == nil {
+				sb.WriteString(linkText)
+				continue
+			}
+			if url, local := p.LinkForType(target); local {
+				sb.WriteString(p.localLink(target, linkText))
+			} else if url != "" {
+				fmt.Fprintf(sb, "link:%s[%s]", url, linkText)
+			} else {
+				sb.WriteString(linkText)
+			}
+		}
+	}
+}
+
+func (p *asciidocPrinter) plainText(text []comment.Text) string {
+	var sb strings.Builder
+	for _, t := range text {
+		switch t := t.(type) {
+		case comment.Plain:
+			sb.WriteString(string(t))
+		case comment.Italic:
+			sb.WriteString(string(t))
+		}
+	}
+	return strings.ReplaceAll(sb.String(), "\n", " ")
+}
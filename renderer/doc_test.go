@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"go/doc/comment"
+	"strings"
+	"testing"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testDocComment = `GuestbookSpec defines the desired state of a [Guestbook].
+
+# Usage
+
+Entries are listed in order:
+  - first
+  - second
+
+See https://example.com/old-page for more.
+
+	kubectl get guestbooks`
+
+func testDocGVDs() []types.GroupVersionDetails {
+	guestbook := &types.Type{Name: "Guestbook", Package: "example.com/api/v1", Kind: types.StructKind}
+	return []types.GroupVersionDetails{
+		{Types: types.TypeMap{types.Identifier(guestbook): guestbook}},
+	}
+}
+
+func testDocConfig() *config.Config {
+	return &config.Config{Render: config.RenderConfig{
+		ParseDocComments: true,
+		LinkMappings: []*config.LinkMapping{
+			{URL: "https://example.com/old-page", Link: "docs-content://new/page.md", Text: "New page"},
+		},
+	}}
+}
+
+func TestDocToMarkdown(t *testing.T) {
+	m, err := NewMarkdownRenderer(testDocConfig())
+	require.NoError(t, err)
+	m.indexTypes(testDocGVDs())
+
+	expected := "GuestbookSpec defines the desired state of a [Guestbook](#guestbook).\n\n" +
+		"##### Usage\n\n" +
+		"Entries are listed in order:\n\n  - first\n  - second\n\n" +
+		"See [New page](docs-content://new/page.md) for more.\n\n" +
+		"\tkubectl get guestbooks"
+	require.Equal(t, expected, m.RenderDoc(testDocComment))
+}
+
+func TestDocToAsciidoc(t *testing.T) {
+	adr, err := NewAsciidoctorRenderer(testDocConfig())
+	require.NoError(t, err)
+	adr.indexTypes(testDocGVDs())
+
+	expected := "GuestbookSpec defines the desired state of a xref:{anchor_prefix}-example-com-api-v1-guestbook[$$Guestbook$$].\n\n" +
+		"[discrete]\n===== Usage\n\n" +
+		"Entries are listed in order:\n\n* first\n* second\n\n" +
+		"See link:docs-content://new/page.md[New page] for more.\n\n" +
+		"----\nkubectl get guestbooks\n----"
+	require.Equal(t, expected, adr.RenderDoc(testDocComment))
+}
+
+func TestDocCommentsDisabled(t *testing.T) {
+	m, err := NewMarkdownRenderer(&config.Config{})
+	require.NoError(t, err)
+	m.indexTypes(testDocGVDs())
+
+	require.Equal(t, "See [Guestbook].", m.RenderDoc("See [Guestbook]."))
+}
+
+func TestFieldDocCell(t *testing.T) {
+	const doc = `Entries of the guest book.
+
+# Usage
+
+Entries are listed in order:
+  - first
+  - second
+
+Run:
+
+	kubectl get guestbooks
+	kubectl describe guestbook`
+
+	m, err := NewMarkdownRenderer(testDocConfig())
+	require.NoError(t, err)
+	m.indexTypes(testDocGVDs())
+	require.Equal(t, "Entries of the guest book.<br />Usage<br />Entries are listed in order:<br />  - first<br />  - second<br />"+
+		"Run:<br />`kubectl get guestbooks`<br />`kubectl describe guestbook`", m.RenderFieldDoc(doc))
+
+	adr, err := NewAsciidoctorRenderer(testDocConfig())
+	require.NoError(t, err)
+	adr.indexTypes(testDocGVDs())
+	require.Equal(t, "Entries of the guest book.\n\nUsage\n\nEntries are listed in order:\n\n- first +\n- second\n\n"+
+		"Run:\n\n`kubectl get guestbooks` +\n`kubectl describe guestbook`", adr.RenderFieldDoc(doc))
+}
+
+func TestAsciidocListItemBlocks(t *testing.T) {
+	list := &comment.List{Items: []*comment.ListItem{{Content: []comment.Block{
+		&comment.Paragraph{Text: []comment.Text{comment.Plain("Run:")}},
+		&comment.Code{Text: "kubectl get guestbooks\n"},
+	}}}}
+
+	var sb strings.Builder
+	(&asciidocPrinter{}).blocks(&sb, []comment.Block{list})
+	require.Equal(t, "* Run:\n+\n----\nkubectl get guestbooks\n----\n\n", sb.String())
+
+	sb.Reset()
+	(&asciidocPrinter{cell: true}).blocks(&sb, []comment.Block{list})
+	require.Equal(t, "- Run: +\n`kubectl get guestbooks`\n\n", sb.String())
+}
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -30,14 +31,17 @@ import (
 )
 
 const (
-	kubePackagesRegex   = `^k8s\.io/(?:api|apimachinery|apiextensions-apiserver/pkg/apis)/`
-	kubeDocLinkTemplate = `https://kubernetes.io/docs/reference/generated/kubernetes-api/v{{ .kubeVersion }}/#{{ .type }}-{{ .version }}-{{ .group }}`
+	pkgGoDevURL = "https://pkg.go.dev"
 )
 
 type Functions struct {
 	conf *config.Config
 	*kubernetesHelper
 	safeIDRegex *regexp.Regexp
+	linkRules   []*linkRule
+	pkgGoDev    *pkgGoDevHelper
+	inventory   map[string]string
+	types       *typeIndex
 }
 
 func NewFunctions(conf *config.Config) (*Functions, error) {
@@ -51,10 +55,28 @@ func NewFunctions(conf *config.Config) (*Functions, error) {
 		return nil, fmt.Errorf("failed to compile safe ID regex: %w", err)
 	}
 
+	linkRules, err := newLinkRules(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgGoDev, err := newPkgGoDevHelper(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory, err := loadInventories(conf.Render.Inventories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load inventories: %w", err)
+	}
+
 	return &Functions{
 		conf:             conf,
 		kubernetesHelper: kubeHelper,
 		safeIDRegex:      safeIDRegex,
+		linkRules:        linkRules,
+		pkgGoDev:         pkgGoDev,
+		inventory:        inventory,
 	}, nil
 }
 
@@ -70,8 +92,15 @@ func (f *Functions) SafeID(id string) string {
 	return strings.ToLower(f.safeIDRegex.ReplaceAllLiteralString(id, "-"))
 }
 
+// LinkForType returns the link to the documentation of t. Known types take precedence over link rules, which take
+// precedence over the types listed in the inventories of other projects and then over the built-in Kubernetes links,
+// unless the Kubernetes type is documented locally.
+// Imported types that are not documented otherwise are linked to pkg.go.dev when enabled.
 func (f *Functions) LinkForType(t *types.Type) (link string, local bool) {
 	if f.IsKubeType(t) {
+		if f.types.lookup(t.Package, t.Name) != nil {
+			return f.TypeID(t), true
+		}
 		return f.LinkForKubeType(t), false
 	}
 
@@ -79,6 +108,26 @@ func (f *Functions) LinkForType(t *types.Type) (link string, local bool) {
 		return f.LinkForKnownType(kt), false
 	}
 
+	if lr, ok := f.linkRuleFor(t); ok {
+		link, err := lr.linkFor(t)
+		if err != nil {
+			zap.S().Warnw("Failed to render link rule", "type", t, "error", err)
+		}
+		return link, false
+	}
+
+	if f.types.lookup(t.Package, t.Name) == nil {
+		if link, ok := f.LinkForInventoryType(t); ok {
+			return link, false
+		}
+	}
+
+	if t.Module != nil && f.types.lookup(t.Package, t.Name) == nil {
+		if link := f.pkgGoDev.linkFor(t); link != "" {
+			return link, false
+		}
+	}
+
 	if t.IsBasic() || t.Imported {
 		return "", false
 	}
@@ -86,9 +135,94 @@ func (f *Functions) LinkForType(t *types.Type) (link string, local bool) {
 	return f.TypeID(t), true
 }
 
+// FieldOrder returns the configured order of the fields of each type.
+func (f *Functions) FieldOrder() types.FieldOrder {
+	return f.conf.Render.FieldOrder
+}
+
+// TypeOrder returns the configured order of the types of each group version.
+func (f *Functions) TypeOrder() types.TypeOrder {
+	return f.conf.Render.TypeOrder
+}
+
+// ObservedStateMode returns the configured rendering of the observed state of the given kind.
+func (f *Functions) ObservedStateMode(kind string) config.ObservedStateMode {
+	obs := f.conf.Render.ObservedState
+	if obs == nil {
+		return config.ObservedStateShow
+	}
+	if mode := obs.Kinds[kind]; mode != "" {
+		return mode
+	}
+	if obs.Mode != "" {
+		return obs.Mode
+	}
+	return config.ObservedStateShow
+}
+
+// ShouldRenderField reports whether the given field of parent is documented. The status field of a root kind is left
+// out when the observed state of the kind is omitted.
+func (f *Functions) ShouldRenderField(parent *types.Type, field *types.Field) bool {
+	return parent.GVK == nil || field.Name != types.StatusFieldName ||
+		f.ObservedStateMode(parent.GVK.Kind) != config.ObservedStateOmit
+}
+
+// IsCollapsed reports whether t is rendered in the collapsed observed state section of a kind.
+func (f *Functions) IsCollapsed(t *types.Type) bool {
+	if !t.ObservedState {
+		return false
+	}
+	kind := t.ObservedStateKind(f.isObservedStateOmitted)
+	return kind != "" && f.ObservedStateMode(kind) == config.ObservedStateCollapse
+}
+
+// ObservedStateSections returns the observed state sections of gvd in the given order. Types shared by several kinds
+// are listed with the first kind whose observed state is not omitted.
+func (f *Functions) ObservedStateSections(gvd types.GroupVersionDetails, order types.TypeOrder) []types.ObservedStateSection {
+	return gvd.ObservedStateSections(order, f.isObservedStateOmitted)
+}
+
+func (f *Functions) isObservedStateOmitted(kind string) bool {
+	return f.ObservedStateMode(kind) == config.ObservedStateOmit
+}
+
+// FieldNotes returns the notes rendered after the documentation of a field, describing its deprecation, its merge
+// semantics, the restrictions on its updates and the schema properties relaxing its validation.
+func (f *Functions) FieldNotes(field *types.Field) []string {
+	var notes []string
+	if field.Deprecation != nil {
+		notes = append(notes, field.Deprecation.String())
+	}
+	if field.MergeSemantics != nil {
+		notes = append(notes, "Merge: "+field.MergeSemantics.String())
+	}
+	notes = append(notes, field.Mutability.Notes()...)
+	return append(notes, field.SchemaFlags.Notes()...)
+}
+
+// KubeTypes returns the documented Kubernetes types referenced by the given group versions, sorted by name.
+func (f *Functions) KubeTypes(gvds []types.GroupVersionDetails) []*types.Type {
+	seen := make(map[string]struct{})
+	var kubeTypes []*types.Type
+	for _, gvd := range gvds {
+		for _, t := range gvd.SortedKubeTypes() {
+			if _, ok := seen[types.Identifier(t)]; !ok {
+				seen[types.Identifier(t)] = struct{}{}
+				kubeTypes = append(kubeTypes, t)
+			}
+		}
+	}
+
+	sort.SliceStable(kubeTypes, func(i, j int) bool {
+		return kubeTypes[i].Name < kubeTypes[j].Name
+	})
+
+	return kubeTypes
+}
+
 func (f *Functions) SimplifiedTypeName(t *types.Type) string {
 	if !t.IsBasic() {
-		return t.Name
+		return t.DisplayName()
 	}
 
 	switch t.Kind {
@@ -132,28 +266,217 @@ func (f *Functions) LinkForKnownType(kt *config.KnownType) string {
 	return kt.Link
 }
 
+type linkRule struct {
+	packageRegex *regexp.Regexp
+	nameRegexes  []*regexp.Regexp
+	linkTemplate *template.Template
+}
+
+// newLinkRules compiles the link rules of the configuration, reporting invalid rules.
+func newLinkRules(conf *config.Config) ([]*linkRule, error) {
+	rules := make([]*linkRule, len(conf.Render.LinkRules))
+	for i, lr := range conf.Render.LinkRules {
+		if lr.Package == "" || lr.Link == "" {
+			return nil, fmt.Errorf("render.linkRules[%d]: package and link are both required", i)
+		}
+
+		packageRegex, err := regexp.Compile(lr.Package)
+		if err != nil {
+			return nil, fmt.Errorf("render.linkRules[%d]: invalid package regular expression %q: %w", i, lr.Package, err)
+		}
+
+		nameRegexes, err := compileRegexes(lr.Names)
+		if err != nil {
+			return nil, fmt.Errorf("render.linkRules[%d]: invalid name regular expression %w", i, err)
+		}
+
+		linkTemplate, err := template.New("").Option("missingkey=error").Parse(lr.Link)
+		if err != nil {
+			return nil, fmt.Errorf("render.linkRules[%d]: invalid link template: %w", i, err)
+		}
+
+		rule := &linkRule{packageRegex: packageRegex, nameRegexes: nameRegexes, linkTemplate: linkTemplate}
+		// report the templates using unknown keys now rather than while rendering
+		if _, err := rule.linkFor(&types.Type{Name: "Guestbook", Package: "example.com/api/v1", APIGroup: "example.com"}); err != nil {
+			return nil, fmt.Errorf("render.linkRules[%d]: invalid link template: %w", i, err)
+		}
+		rules[i] = rule
+	}
+
+	return rules, nil
+}
+
+func (f *Functions) linkRuleFor(t *types.Type) (*linkRule, bool) {
+	if t.Package == "" {
+		return nil, false
+	}
+
+	for _, lr := range f.linkRules {
+		if lr.matches(t) {
+			return lr, true
+		}
+	}
+
+	return nil, false
+}
+
+func (lr *linkRule) matches(t *types.Type) bool {
+	if !lr.packageRegex.MatchString(t.Package) {
+		return false
+	}
+
+	if len(lr.nameRegexes) == 0 {
+		return true
+	}
+
+	for _, re := range lr.nameRegexes {
+		if re.MatchString(t.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// linkFor renders the link to t. The group is the +groupName of the package of t and the version is the last element
+// of the package path, e.g. "gateway.networking.k8s.io" and "v1beta1" for "sigs.k8s.io/gateway-api/apis/v1beta1".
+// Packages without a +groupName use the element of the path preceding the version as the group.
+func (lr *linkRule) linkFor(t *types.Type) (string, error) {
+	parts := strings.Split(t.Package, "/")
+	version := parts[len(parts)-1]
+	group := t.APIGroup
+	if group == "" && len(parts) > 1 {
+		group = parts[len(parts)-2]
+	}
+
+	return lr.render(map[string]string{
+		"group":     group,
+		"version":   version,
+		"name":      t.Name,
+		"lowerName": strings.ToLower(t.Name),
+		"package":   t.Package,
+	})
+}
+
+func (lr *linkRule) render(args map[string]string) (string, error) {
+	s := new(bytes.Buffer)
+	if err := lr.linkTemplate.Execute(s, args); err != nil {
+		return "", err
+	}
+
+	return s.String(), nil
+}
+
+type pkgGoDevHelper struct {
+	enabled bool
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+func newPkgGoDevHelper(conf *config.Config) (*pkgGoDevHelper, error) {
+	pgd := conf.Render.PkgGoDev
+	if pgd == nil || !pgd.Enabled {
+		return &pkgGoDevHelper{}, nil
+	}
+
+	include, err := compileRegexes(pgd.Include)
+	if err != nil {
+		return nil, fmt.Errorf("render.pkgGoDev.include: invalid regular expression %w", err)
+	}
+
+	exclude, err := compileRegexes(pgd.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("render.pkgGoDev.exclude: invalid regular expression %w", err)
+	}
+
+	return &pkgGoDevHelper{enabled: true, include: include, exclude: exclude}, nil
+}
+
+// linkFor returns the pkg.go.dev link to t, pinned to the version of the module providing it, or an empty string if
+// t should not be linked.
+func (h *pkgGoDevHelper) linkFor(t *types.Type) string {
+	if !h.enabled || t.Module == nil || !h.allows(t.Package) {
+		return ""
+	}
+
+	subPath := strings.TrimPrefix(t.Package, t.Module.Path)
+	if subPath != "" && !strings.HasPrefix(subPath, "/") {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s@%s%s#%s", pkgGoDevURL, t.Module.Path, t.Module.Version, subPath, t.Name)
+}
+
+func (h *pkgGoDevHelper) allows(pkg string) bool {
+	for _, re := range h.exclude {
+		if re.MatchString(pkg) {
+			return false
+		}
+	}
+
+	if len(h.include) == 0 {
+		return true
+	}
+
+	for _, re := range h.include {
+		if re.MatchString(pkg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func compileRegexes(exprs []string) ([]*regexp.Regexp, error) {
+	regexes := make([]*regexp.Regexp, len(exprs))
+	for i, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", expr, err)
+		}
+		regexes[i] = re
+	}
+
+	return regexes, nil
+}
+
 type kubernetesHelper struct {
 	kubeVersion     string
 	packagesRegex   *regexp.Regexp
 	docLinkTemplate *template.Template
+	// singlePageTemplate links the types that have no page in the per-resource reference, with the per-resource preset.
+	singlePageTemplate *template.Template
 }
 
 func newKubernetesHelper(conf *config.Config) (*kubernetesHelper, error) {
-	packagesRegex, err := regexp.Compile(kubePackagesRegex)
+	packagesRegex, err := regexp.Compile(config.KubePackagesRegex)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile kube package regex: %w", err)
 	}
 
-	docLinkTemplate, err := template.New("").Parse(kubeDocLinkTemplate)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse kube doc link template: %w", err)
-	}
-
-	return &kubernetesHelper{
+	singlePageTemplate := template.Must(template.New("").Parse(kubeSinglePageLinkTemplate))
+	h := &kubernetesHelper{
 		kubeVersion:     conf.Render.KubernetesVersion,
 		packagesRegex:   packagesRegex,
-		docLinkTemplate: docLinkTemplate,
-	}, nil
+		docLinkTemplate: singlePageTemplate,
+	}
+
+	if kl := conf.Render.KubernetesLinks; kl != nil {
+		if kl.Preset == config.KubernetesLinkPresetPerResource {
+			h.docLinkTemplate = template.Must(template.New("").Parse(kubePerResourceLinkTemplate))
+			h.singlePageTemplate = singlePageTemplate
+		}
+		if kl.Template != "" {
+			docLinkTemplate, err := template.New("").Parse(kl.Template)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse kube doc link template: %w", err)
+			}
+			h.docLinkTemplate = docLinkTemplate
+			h.singlePageTemplate = nil
+		}
+	}
+
+	return h, nil
 }
 
 func (k *kubernetesHelper) IsKubeType(t *types.Type) bool {
@@ -170,6 +493,12 @@ func (k *kubernetesHelper) LinkForKubeType(t *types.Type) string {
 		zap.S().Fatalw("Unexpected Kubernetes package name", "type", t)
 	}
 	group := strings.ToLower(parts[len(parts)-2])
+	version := strings.ToLower(parts[len(parts)-1])
+	page, found := lookupKubeResourcePage(group, version, t.Name)
+	docLinkTemplate := k.docLinkTemplate
+	if k.singlePageTemplate != nil && (!found || page.singlePage) {
+		docLinkTemplate = k.singlePageTemplate
+	}
 	// this is alias handling
 	if group == "apiextensions" {
 		group = "apiextensions-k8s-io"
@@ -177,12 +506,15 @@ func (k *kubernetesHelper) LinkForKubeType(t *types.Type) string {
 	args := map[string]string{
 		"kubeVersion": k.kubeVersion,
 		"group":       group,
-		"version":     strings.ToLower(parts[len(parts)-1]),
+		"version":     version,
 		"type":        strings.ToLower(t.Name),
+		"name":        t.Name,
+		"page":        page.page,
+		"anchor":      page.anchor,
 	}
 
 	s := new(bytes.Buffer)
-	if err := k.docLinkTemplate.Execute(s, args); err != nil {
+	if err := docLinkTemplate.Execute(s, args); err != nil {
 		zap.S().Fatalw("Failed to render Kube doc link", "type", t, "error", err)
 	}
 
@@ -22,6 +22,7 @@ import (
 	"github.com/elastic/crd-ref-docs/config"
 	"github.com/elastic/crd-ref-docs/types"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestKubernetesHelper(t *testing.T) {
@@ -55,3 +56,346 @@ func TestKubernetesHelper(t *testing.T) {
 		})
 	}
 }
+
+func TestLinkForType(t *testing.T) {
+	conf := config.Config{
+		Render: config.RenderConfig{
+			KubernetesVersion: "1.29",
+			KnownTypes: []*config.KnownType{
+				{Name: "Gateway", Package: "sigs.k8s.io/gateway-api/apis/v1beta1", Link: "https://example.com/gateway"},
+			},
+			LinkRules: []*config.LinkRule{
+				{
+					Package: `^sigs\.k8s\.io/gateway-api/apis/`,
+					Names:   []string{"Reference$"},
+					Link:    "https://gateway-api.sigs.k8s.io/references/spec/#gateway.networking.k8s.io/{{ .version }}.{{ .name }}",
+				},
+				{
+					Package: `^sigs\.k8s\.io/gateway-api/`,
+					Link:    "https://example.com/{{ .group }}/{{ .version }}/{{ .lowerName }}",
+				},
+				{
+					Package: `^k8s\.io/api/core/`,
+					Link:    "https://example.com/core/{{ .package }}.{{ .name }}",
+				},
+			},
+		},
+	}
+
+	f, err := NewFunctions(&conf)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		input    *types.Type
+		expected string
+		local    bool
+	}{
+		{
+			name:     "known type takes precedence",
+			input:    &types.Type{Package: "sigs.k8s.io/gateway-api/apis/v1beta1", Name: "Gateway"},
+			expected: "https://example.com/gateway",
+		},
+		{
+			name:     "first matching rule",
+			input:    &types.Type{Package: "sigs.k8s.io/gateway-api/apis/v1beta1", Name: "SecretObjectReference"},
+			expected: "https://gateway-api.sigs.k8s.io/references/spec/#gateway.networking.k8s.io/v1beta1.SecretObjectReference",
+		},
+		{
+			name:     "rule without names",
+			input:    &types.Type{Package: "sigs.k8s.io/gateway-api/apis/v1beta1", Name: "HTTPRoute", APIGroup: "gateway.networking.k8s.io"},
+			expected: "https://example.com/gateway.networking.k8s.io/v1beta1/httproute",
+		},
+		{
+			name:     "rule for a package without group name",
+			input:    &types.Type{Package: "sigs.k8s.io/gateway-api/apis/v1beta1", Name: "HTTPRoute"},
+			expected: "https://example.com/apis/v1beta1/httproute",
+		},
+		{
+			name:     "kube link takes precedence over rules",
+			input:    &types.Type{Package: "k8s.io/api/core/v1", Name: "Secret"},
+			expected: "https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.29/#secret-v1-core",
+		},
+		{
+			name:     "kube link",
+			input:    &types.Type{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ObjectMeta"},
+			expected: "https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.29/#objectmeta-v1-meta",
+		},
+		{
+			name:     "local type",
+			input:    &types.Type{Package: "example.com/api/v1", Name: "Guestbook"},
+			expected: "example-com-api-v1-guestbook",
+			local:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			link, local := f.LinkForType(tc.input)
+			require.Equal(t, tc.expected, link)
+			require.Equal(t, tc.local, local)
+		})
+	}
+}
+
+func TestNewLinkRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    config.LinkRule
+		wantErr string
+	}{
+		{
+			name: "valid rule",
+			rule: config.LinkRule{Package: `^sigs\.k8s\.io/gateway-api/`, Names: []string{"Reference$"}, Link: "https://example.com/{{ .version }}.{{ .name }}"},
+		},
+		{
+			name:    "missing link",
+			rule:    config.LinkRule{Package: `^sigs\.k8s\.io/gateway-api/`},
+			wantErr: "render.linkRules[0]: package and link are both required",
+		},
+		{
+			name:    "invalid package regex",
+			rule:    config.LinkRule{Package: "(", Link: "https://example.com/{{ .name }}"},
+			wantErr: "render.linkRules[0]: invalid package regular expression",
+		},
+		{
+			name:    "invalid name regex",
+			rule:    config.LinkRule{Package: "gateway-api", Names: []string{"("}, Link: "https://example.com/{{ .name }}"},
+			wantErr: "render.linkRules[0]: invalid name regular expression",
+		},
+		{
+			name:    "invalid link template",
+			rule:    config.LinkRule{Package: "gateway-api", Link: "https://example.com/{{ .name"},
+			wantErr: "render.linkRules[0]: invalid link template",
+		},
+		{
+			name:    "unknown link template key",
+			rule:    config.LinkRule{Package: "gateway-api", Link: "https://example.com/{{ .kind }}"},
+			wantErr: `render.linkRules[0]: invalid link template: template: :1:23: executing "" at <.kind>: map has no entry for key "kind"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFunctions(&config.Config{Render: config.RenderConfig{LinkRules: []*config.LinkRule{&tt.rule}}})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewPkgGoDevHelper(t *testing.T) {
+	_, err := NewFunctions(&config.Config{Render: config.RenderConfig{PkgGoDev: &config.PkgGoDevConfig{
+		Enabled: true,
+		Exclude: []string{"("},
+	}}})
+	require.ErrorContains(t, err, `render.pkgGoDev.exclude: invalid regular expression "("`)
+}
+
+func TestLinkForTypePkgGoDev(t *testing.T) {
+	conf := config.Config{
+		Render: config.RenderConfig{
+			PkgGoDev: &config.PkgGoDevConfig{
+				Enabled: true,
+				Exclude: []string{`^example\.com/private/`},
+			},
+		},
+	}
+
+	f, err := NewFunctions(&conf)
+	require.NoError(t, err)
+
+	gatewayModule := &types.Module{Path: "sigs.k8s.io/gateway-api", Version: "v0.7.1"}
+	documented := &types.Type{Package: "sigs.k8s.io/gateway-api/apis/v1beta1", Name: "Gateway", Module: gatewayModule}
+	f.indexTypes([]types.GroupVersionDetails{{Types: types.TypeMap{"Gateway": documented}}})
+
+	cases := []struct {
+		name     string
+		input    *types.Type
+		expected string
+		local    bool
+	}{
+		{
+			name:     "imported type",
+			input:    &types.Type{Package: "sigs.k8s.io/gateway-api/apis/v1beta1", Name: "SecretObjectReference", Module: gatewayModule},
+			expected: "https://pkg.go.dev/sigs.k8s.io/gateway-api@v0.7.1/apis/v1beta1#SecretObjectReference",
+		},
+		{
+			name:     "type at the module root",
+			input:    &types.Type{Package: "example.com/lib", Name: "Config", Module: &types.Module{Path: "example.com/lib", Version: "v1.2.3"}},
+			expected: "https://pkg.go.dev/example.com/lib@v1.2.3#Config",
+		},
+		{
+			name:     "documented type",
+			input:    documented,
+			expected: "sigs-k8s-io-gateway-api-apis-v1beta1-gateway",
+			local:    true,
+		},
+		{
+			name:     "excluded package",
+			input:    &types.Type{Package: "example.com/private/api", Name: "Secret", Module: &types.Module{Path: "example.com/private", Version: "v0.1.0"}},
+			expected: "example-com-private-api-secret",
+			local:    true,
+		},
+		{
+			name:     "type without module",
+			input:    &types.Type{Package: "example.com/api/v1", Name: "Guestbook"},
+			expected: "example-com-api-v1-guestbook",
+			local:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			link, local := f.LinkForType(tc.input)
+			require.Equal(t, tc.expected, link)
+			require.Equal(t, tc.local, local)
+		})
+	}
+}
+
+func TestKubeTypes(t *testing.T) {
+	f, err := NewFunctions(&config.Config{Render: config.RenderConfig{KubernetesVersion: "1.29"}})
+	require.NoError(t, err)
+
+	selector := &types.Type{Name: "LabelSelector", Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Kind: types.StructKind}
+	resources := &types.Type{Name: "ResourceRequirements", Package: "k8s.io/api/core/v1", Kind: types.StructKind}
+	gvds := []types.GroupVersionDetails{
+		{KubeTypes: types.TypeMap{types.Identifier(selector): selector, types.Identifier(resources): resources}},
+		{KubeTypes: types.TypeMap{types.Identifier(selector): selector}},
+	}
+	require.Equal(t, []*types.Type{selector, resources}, f.KubeTypes(gvds))
+
+	f.indexTypes(gvds)
+	link, local := f.LinkForType(selector)
+	require.Equal(t, "k8s-io-apimachinery-pkg-apis-meta-v1-labelselector", link)
+	require.True(t, local)
+
+	link, local = f.LinkForType(&types.Type{Name: "ObjectMeta", Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Kind: types.StructKind})
+	require.Equal(t, "https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.29/#objectmeta-v1-meta", link)
+	require.False(t, local)
+}
+
+func TestObservedStateMode(t *testing.T) {
+	f, err := NewFunctions(&config.Config{})
+	require.NoError(t, err)
+
+	kind := &types.Type{Name: "Guestbook", GVK: &schema.GroupVersionKind{Kind: "Guestbook"}}
+	status := &types.Field{Name: "status"}
+	statusType := &types.Type{Name: "GuestbookStatus", ObservedState: true, ObservedStateOf: []string{"Guestbook"}}
+	require.Equal(t, config.ObservedStateShow, f.ObservedStateMode("Guestbook"))
+	require.True(t, f.ShouldRenderField(kind, status))
+	require.False(t, f.IsCollapsed(statusType))
+
+	f.conf.Render.ObservedState = &config.ObservedStateConfig{
+		Mode:  config.ObservedStateCollapse,
+		Kinds: map[string]config.ObservedStateMode{"Guestbook": config.ObservedStateOmit},
+	}
+	require.Equal(t, config.ObservedStateOmit, f.ObservedStateMode("Guestbook"))
+	require.Equal(t, config.ObservedStateCollapse, f.ObservedStateMode("Library"))
+	require.False(t, f.ShouldRenderField(kind, status))
+	require.True(t, f.ShouldRenderField(kind, &types.Field{Name: "spec"}))
+	require.True(t, f.ShouldRenderField(&types.Type{Name: "GuestbookStatus"}, status))
+	require.False(t, f.IsCollapsed(statusType))
+	require.True(t, f.IsCollapsed(&types.Type{Name: "LibraryStatus", ObservedState: true, ObservedStateOf: []string{"Library"}}))
+}
+
+func TestObservedStateSectionsMixedModes(t *testing.T) {
+	f, err := NewFunctions(&config.Config{Render: config.RenderConfig{ObservedState: &config.ObservedStateConfig{
+		Kinds: map[string]config.ObservedStateMode{"A": config.ObservedStateOmit, "B": config.ObservedStateShow},
+	}}})
+	require.NoError(t, err)
+
+	condition := &types.Type{Name: "Condition", ObservedState: true, ObservedStateOf: []string{"A", "B"}}
+	aStatus := &types.Type{Name: "AStatus", ObservedState: true, ObservedStateOf: []string{"A"}}
+	bStatus := &types.Type{Name: "BStatus", ObservedState: true, ObservedStateOf: []string{"B"}}
+	gvd := types.GroupVersionDetails{Types: types.TypeMap{"Condition": condition, "AStatus": aStatus, "BStatus": bStatus}}
+
+	sections := f.ObservedStateSections(gvd, types.TypeOrderAlphabetical)
+	require.Len(t, sections, 1)
+	require.Equal(t, "B", sections[0].Kind)
+	require.Equal(t, []*types.Type{bStatus, condition}, sections[0].Types)
+
+	f.conf.Render.ObservedState.Kinds["B"] = config.ObservedStateCollapse
+	require.True(t, f.IsCollapsed(condition))
+	require.False(t, f.IsCollapsed(aStatus))
+}
+
+func TestFieldNotes(t *testing.T) {
+	f, err := NewFunctions(&config.Config{})
+	require.NoError(t, err)
+
+	require.Empty(t, f.FieldNotes(&types.Field{Name: "name"}))
+	require.Equal(t, []string{"Merge: list keyed by `name`", "may be set to `null`"}, f.FieldNotes(&types.Field{
+		Name:           "entries",
+		MergeSemantics: &types.MergeSemantics{ListType: "map", ListMapKeys: []string{"name"}},
+		SchemaFlags:    types.SchemaFlags{Nullable: true},
+	}))
+	require.Equal(t, []string{"Deprecated. Use `rating` instead."}, f.FieldNotes(&types.Field{
+		Name:        "score",
+		Deprecation: &types.Deprecation{Replacement: "rating"},
+	}))
+}
+
+func TestKubernetesHelperLinkStyles(t *testing.T) {
+	cases := []struct {
+		name     string
+		links    *config.KubernetesLinksConfig
+		input    *types.Type
+		expected string
+	}{
+		{
+			name:     "per-resource page",
+			links:    &config.KubernetesLinksConfig{Preset: config.KubernetesLinkPresetPerResource},
+			input:    &types.Type{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "LabelSelector"},
+			expected: "https://kubernetes.io/docs/reference/kubernetes-api/common-definitions/label-selector/",
+		},
+		{
+			name:     "per-resource anchor",
+			links:    &config.KubernetesLinksConfig{Preset: config.KubernetesLinkPresetPerResource},
+			input:    &types.Type{Package: "k8s.io/api/core/v1", Name: "PodSpec"},
+			expected: "https://kubernetes.io/docs/reference/kubernetes-api/workload-resources/pod-v1/#PodSpec",
+		},
+		{
+			name:     "per-resource sub-type",
+			links:    &config.KubernetesLinksConfig{Preset: config.KubernetesLinkPresetPerResource},
+			input:    &types.Type{Package: "k8s.io/api/core/v1", Name: "Container"},
+			expected: "https://kubernetes.io/docs/reference/kubernetes-api/workload-resources/pod-v1/#Container",
+		},
+		{
+			name:     "per-resource unmapped type",
+			links:    &config.KubernetesLinksConfig{Preset: config.KubernetesLinkPresetPerResource},
+			input:    &types.Type{Package: "k8s.io/api/core/v1", Name: "SecretKeySelector"},
+			expected: "https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.29/#secretkeyselector-v1-core",
+		},
+		{
+			name:     "per-resource single-page type",
+			links:    &config.KubernetesLinksConfig{Preset: config.KubernetesLinkPresetPerResource},
+			input:    &types.Type{Package: "k8s.io/apimachinery/pkg/util/intstr", Name: "IntOrString"},
+			expected: "https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.29/#intorstring-intstr-util",
+		},
+		{
+			name:     "per-resource time",
+			links:    &config.KubernetesLinksConfig{Preset: config.KubernetesLinkPresetPerResource},
+			input:    &types.Type{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "Time"},
+			expected: "https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.29/#time-v1-meta",
+		},
+		{
+			name:     "custom template",
+			links:    &config.KubernetesLinksConfig{Template: "https://k8s.example.com/{{ .kubeVersion }}/{{ .group }}/{{ .version }}/{{ .name }}"},
+			input:    &types.Type{Package: "k8s.io/api/core/v1", Name: "Secret"},
+			expected: "https://k8s.example.com/1.29/core/v1/Secret",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			kh, err := newKubernetesHelper(&config.Config{Render: config.RenderConfig{KubernetesVersion: "1.29", KubernetesLinks: tc.links}})
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, kh.LinkForKubeType(tc.input))
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
+)
+
+const (
+	inventoryVersion    = 1
+	defaultInventoryURL = "{{ .File }}#{{ .Anchor }}"
+)
+
+// Inventory maps the identifiers of the types documented by a project (e.g. "example.com/api/v1.Guestbook") to the
+// URL of their documentation, so that other projects can link to them.
+type Inventory struct {
+	Version int               `json:"version"`
+	Types   map[string]string `json:"types"`
+}
+
+// inventoryEntry holds the values available to the URL template of the inventory.
+type inventoryEntry struct {
+	File    string
+	Page    string
+	Anchor  string
+	Group   string
+	Version string
+	Name    string
+	Package string
+}
+
+// LoadInventory reads the inventory file at path.
+func LoadInventory(path string) (*Inventory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var inv Inventory
+	if err := json.Unmarshal(data, &inv); err != nil {
+		return nil, fmt.Errorf("failed to parse inventory %s: %w", path, err)
+	}
+
+	if inv.Version != inventoryVersion {
+		return nil, fmt.Errorf("unsupported version %d of inventory %s", inv.Version, path)
+	}
+
+	return &inv, nil
+}
+
+// loadInventories merges the inventories at paths. When a type is listed in several inventories, the first one wins.
+func loadInventories(paths []string) (map[string]string, error) {
+	links := make(map[string]string)
+	for _, p := range paths {
+		inv, err := LoadInventory(p)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, url := range inv.Types {
+			if _, ok := links[id]; !ok {
+				links[id] = url
+			}
+		}
+	}
+
+	return links, nil
+}
+
+// LinkForInventoryType returns the link to a type documented by another project, as listed in the configured
+// inventories.
+func (f *Functions) LinkForInventoryType(t *types.Type) (string, bool) {
+	link, ok := f.inventory[types.Identifier(t)]
+	return link, ok
+}
+
+// inventoryRenderer locates the types documented by a renderer.
+type inventoryRenderer interface {
+	// documentedTypes returns the types of gvd that are rendered with a heading.
+	documentedTypes(gvd types.GroupVersionDetails) []*types.Type
+	// anchor returns the anchor of the heading documenting t in file.
+	anchor(file outputFile, t *types.Type) string
+}
+
+// renderedTypes returns the types of gvd rendered by the default templates, i.e. accepted by shouldRender: the types
+// of the type groups, and the observed state types of the kinds whose observed state is not omitted.
+func (f *Functions) renderedTypes(gvd types.GroupVersionDetails, shouldRender func(t *types.Type) bool) []*types.Type {
+	var rendered []*types.Type
+	for _, group := range gvd.TypeGroups(f.TypeOrder()) {
+		for _, t := range group.Types {
+			if !t.ObservedState && shouldRender(t) {
+				rendered = append(rendered, t)
+			}
+		}
+	}
+	for _, section := range f.ObservedStateSections(gvd, f.TypeOrder()) {
+		for _, t := range section.Types {
+			if shouldRender(t) {
+				rendered = append(rendered, t)
+			}
+		}
+	}
+	return rendered
+}
+
+// buildInventory lists the types documented in files, with the URL rendered by the configured template.
+func buildInventory(conf *config.InventoryConfig, files []outputFile, r inventoryRenderer) (*Inventory, error) {
+	urlTemplate := conf.URL
+	if urlTemplate == "" {
+		urlTemplate = defaultInventoryURL
+	}
+
+	tmpl, err := template.New("").Option("missingkey=error").Parse(urlTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse inventory URL template: %w", err)
+	}
+
+	inv := &Inventory{Version: inventoryVersion, Types: make(map[string]string)}
+	for _, file := range files {
+		for _, gvd := range file.gvds {
+			for _, t := range r.documentedTypes(gvd) {
+				entry := inventoryEntry{
+					File:    file.name,
+					Page:    strings.TrimSuffix(file.name, path.Ext(file.name)),
+					Anchor:  r.anchor(file, t),
+					Group:   gvd.Group,
+					Version: gvd.Version,
+					Name:    t.Name,
+					Package: t.Package,
+				}
+
+				buf := new(bytes.Buffer)
+				if err := tmpl.Execute(buf, entry); err != nil {
+					return nil, fmt.Errorf("failed to render inventory URL of %s: %w", types.Identifier(t), err)
+				}
+				inv.Types[types.Identifier(t)] = buf.String()
+			}
+		}
+	}
+
+	return inv, nil
+}
+
+// writeInventory writes the inventory of the types documented in files, if configured.
+func writeInventory(conf *config.Config, files []outputFile, r inventoryRenderer) error {
+	if conf.Render.Inventory == nil {
+		return nil
+	}
+
+	inv, err := buildInventory(conf.Render.Inventory, files, r)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(conf.Render.Inventory.Path, append(data, '\n'), 0o644)
+}
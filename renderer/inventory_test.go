@@ -0,0 +1,146 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestWriteInventory(t *testing.T) {
+	guestbook := &types.Type{Name: "Guestbook", Package: "example.com/api/v1", Kind: types.StructKind,
+		GVK: &schema.GroupVersionKind{Group: "webapp.example.com", Version: "v1", Kind: "Guestbook"}}
+	spec := &types.Type{Name: "GuestbookSpec", Package: "example.com/api/v1", Kind: types.StructKind,
+		References: []*types.Type{guestbook}}
+	unused := &types.Type{Name: "Unused", Package: "example.com/api/v1", Kind: types.StructKind}
+	status := &types.Type{Name: "GuestbookStatus", Package: "example.com/api/v1", Kind: types.StructKind,
+		References: []*types.Type{guestbook}, ObservedState: true, ObservedStateOf: []string{"Guestbook"}}
+	gvd := types.GroupVersionDetails{
+		GroupVersion: schema.GroupVersion{Group: "webapp.example.com", Version: "v1"},
+		Types:        types.TypeMap{"Guestbook": guestbook, "GuestbookSpec": spec, "Unused": unused, "GuestbookStatus": status},
+	}
+
+	path := filepath.Join(t.TempDir(), "inventory.json")
+	conf := &config.Config{Render: config.RenderConfig{Inventory: &config.InventoryConfig{
+		Path: path,
+		URL:  "https://example.com/{{ .Group }}/{{ .Page }}/#{{ .Anchor }}",
+	}}}
+
+	m, err := NewMarkdownRenderer(conf)
+	require.NoError(t, err)
+
+	files := []outputFile{{name: "webapp.example.com.md", gvds: []types.GroupVersionDetails{gvd}}}
+	require.NoError(t, writeInventory(conf, files, m))
+
+	inv, err := LoadInventory(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"example.com/api/v1.Guestbook":       "https://example.com/webapp.example.com/webapp.example.com/#guestbook",
+		"example.com/api/v1.GuestbookSpec":   "https://example.com/webapp.example.com/webapp.example.com/#guestbookspec",
+		"example.com/api/v1.GuestbookStatus": "https://example.com/webapp.example.com/webapp.example.com/#guestbookstatus",
+	}, inv.Types)
+
+	// the types whose observed state is omitted are not rendered
+	conf.Render.ObservedState = &config.ObservedStateConfig{Mode: config.ObservedStateOmit}
+	require.NoError(t, writeInventory(conf, files, m))
+
+	inv, err = LoadInventory(path)
+	require.NoError(t, err)
+	require.NotContains(t, inv.Types, "example.com/api/v1.GuestbookStatus")
+	require.Len(t, inv.Types, 2)
+}
+
+func TestWriteInventoryAsciidocAnchorPrefix(t *testing.T) {
+	guestbook := &types.Type{Name: "Guestbook", Package: "example.com/api/v1", Kind: types.StructKind,
+		GVK: &schema.GroupVersionKind{Group: "webapp.example.com", Version: "v1", Kind: "Guestbook"}}
+	gvd := types.GroupVersionDetails{
+		GroupVersion: schema.GroupVersion{Group: "webapp.example.com", Version: "v1"},
+		Types:        types.TypeMap{"Guestbook": guestbook},
+	}
+
+	path := filepath.Join(t.TempDir(), "inventory.json")
+	conf := &config.Config{Render: config.RenderConfig{Inventory: &config.InventoryConfig{Path: path}}}
+
+	adr, err := NewAsciidoctorRenderer(conf)
+	require.NoError(t, err)
+
+	files := []outputFile{{
+		name:    "out.asciidoc",
+		content: []byte(":anchor_prefix: webapp\n\n== API Reference\n"),
+		gvds:    []types.GroupVersionDetails{gvd},
+	}}
+	require.NoError(t, writeInventory(conf, files, adr))
+
+	inv, err := LoadInventory(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"example.com/api/v1.Guestbook": "out.asciidoc#webapp-example-com-api-v1-guestbook",
+	}, inv.Types)
+}
+
+func TestLinkForInventoryType(t *testing.T) {
+	dir := t.TempDir()
+	writeInventoryFile := func(name string, links map[string]string) string {
+		data, err := json.Marshal(Inventory{Version: inventoryVersion, Types: links})
+		require.NoError(t, err)
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		return path
+	}
+
+	conf := &config.Config{Render: config.RenderConfig{
+		Inventories: []string{
+			writeInventoryFile("first.json", map[string]string{
+				"example.com/operator/api/v1.Cluster": "https://example.com/operator/#cluster",
+				"example.com/api/v1.Guestbook":        "https://example.com/other/#guestbook",
+			}),
+			writeInventoryFile("second.json", map[string]string{
+				"example.com/operator/api/v1.Cluster": "https://example.com/second/#cluster",
+			}),
+		},
+	}}
+
+	f, err := NewFunctions(conf)
+	require.NoError(t, err)
+
+	guestbook := &types.Type{Name: "Guestbook", Package: "example.com/api/v1", Kind: types.StructKind}
+	f.indexTypes([]types.GroupVersionDetails{{Types: types.TypeMap{"Guestbook": guestbook}}})
+
+	link, local := f.LinkForType(&types.Type{Name: "Cluster", Package: "example.com/operator/api/v1", Kind: types.StructKind})
+	require.Equal(t, "https://example.com/operator/#cluster", link)
+	require.False(t, local)
+
+	// Types documented locally are not linked to other projects.
+	link, local = f.LinkForType(guestbook)
+	require.Equal(t, "example-com-api-v1-guestbook", link)
+	require.True(t, local)
+}
+
+func TestLoadInventoryVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 2, "types": {}}`), 0o600))
+
+	_, err := LoadInventory(path)
+	require.Error(t, err)
+}
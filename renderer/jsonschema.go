@@ -0,0 +1,386 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
+	"go.uber.org/zap"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-tools/pkg/crd"
+	crdmarkers "sigs.k8s.io/controller-tools/pkg/crd/markers"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+)
+
+const (
+	jsonSchemaDraft        = "http://json-schema.org/draft-07/schema#"
+	validationMarkerPrefix = "kubebuilder:validation:"
+	itemsMarkerPrefix      = "kubebuilder:validation:items:"
+)
+
+// wellKnownSchemas describes the types whose serialized form differs from their Go structure.
+var wellKnownSchemas = map[string]apiextensionsv1.JSONSchemaProps{
+	"k8s.io/apimachinery/pkg/apis/meta/v1.Time":                     {Type: "string", Format: "date-time"},
+	"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime":                {Type: "string", Format: "date-time"},
+	"k8s.io/apimachinery/pkg/apis/meta/v1.Duration":                 {Type: "string"},
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":               {Type: "object"},
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta":                 {Type: "object"},
+	"k8s.io/apimachinery/pkg/util/intstr.IntOrString":               intOrStringSchema(),
+	"k8s.io/apimachinery/pkg/api/resource.Quantity":                 intOrStringSchema(),
+	"k8s.io/apimachinery/pkg/runtime.RawExtension":                  preserveUnknownFieldsSchema("object"),
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.JSON": preserveUnknownFieldsSchema(""),
+}
+
+func intOrStringSchema() apiextensionsv1.JSONSchemaProps {
+	return apiextensionsv1.JSONSchemaProps{
+		XIntOrString: true,
+		AnyOf:        []apiextensionsv1.JSONSchemaProps{{Type: "integer"}, {Type: "string"}},
+	}
+}
+
+func preserveUnknownFieldsSchema(schemaType string) apiextensionsv1.JSONSchemaProps {
+	preserve := true
+	return apiextensionsv1.JSONSchemaProps{Type: schemaType, XPreserveUnknownFields: &preserve}
+}
+
+// JSONSchemaRenderer emits a JSON Schema for each root kind, usable by editors and the YAML language server.
+type JSONSchemaRenderer struct {
+	conf *config.Config
+	*Functions
+}
+
+func NewJSONSchemaRenderer(conf *config.Config) (*JSONSchemaRenderer, error) {
+	baseFuncs, err := NewFunctions(conf)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONSchemaRenderer{conf: conf, Functions: baseFuncs}, nil
+}
+
+// Render writes one JSON Schema file per root kind to the output path, which must be an existing directory. If the
+// output path is "-", the files are written to stdout as a tar archive.
+func (r *JSONSchemaRenderer) Render(gvd []types.GroupVersionDetails) error {
+	files, err := r.renderSchemas(gvd)
+	if err != nil {
+		return err
+	}
+
+	if r.conf.OutputPath == config.OutputPathStdout {
+		return writeOutput(os.Stdout, true, files)
+	}
+
+	return writeOutFiles(r.conf.OutputPath, true, files)
+}
+
+// RenderTo writes a tar archive containing one JSON Schema file per root kind to w.
+func (r *JSONSchemaRenderer) RenderTo(w io.Writer, gvd []types.GroupVersionDetails) error {
+	files, err := r.renderSchemas(gvd)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(w, true, files)
+}
+
+func (r *JSONSchemaRenderer) renderSchemas(gvds []types.GroupVersionDetails) ([]outputFile, error) {
+	var files []outputFile
+	for _, gvd := range gvds {
+		for _, kind := range gvd.SortedKinds() {
+			t := gvd.TypeForKind(kind)
+			if t == nil {
+				continue
+			}
+
+			buf := new(bytes.Buffer)
+			enc := json.NewEncoder(buf)
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(r.SchemaForKind(gvd, t)); err != nil {
+				return nil, fmt.Errorf("failed to marshal schema of %s: %w", kind, err)
+			}
+
+			name := fmt.Sprintf("%s_%s_%s.json", gvd.Group, strings.ToLower(kind), gvd.Version)
+			files = append(files, outputFile{name: name, content: buf.Bytes()})
+		}
+	}
+
+	return files, nil
+}
+
+// SchemaForKind builds the JSON Schema of the root kind t of the given group version.
+func (r *JSONSchemaRenderer) SchemaForKind(gvd types.GroupVersionDetails, t *types.Type) *apiextensionsv1.JSONSchemaProps {
+	b := &schemaBuilder{definitions: make(apiextensionsv1.JSONSchemaDefinitions)}
+
+	schema := b.structSchema(t)
+	schema.Schema = jsonSchemaDraft
+	schema.Title = t.Name
+	schema.Properties["apiVersion"] = apiextensionsv1.JSONSchemaProps{
+		Type: "string",
+		Enum: []apiextensionsv1.JSON{jsonValue(gvd.GroupVersionString())},
+	}
+	schema.Properties["kind"] = apiextensionsv1.JSONSchemaProps{
+		Type: "string",
+		Enum: []apiextensionsv1.JSON{jsonValue(t.GVK.Kind)},
+	}
+	if len(b.definitions) > 0 {
+		schema.Definitions = b.definitions
+	}
+
+	return &schema
+}
+
+// schemaBuilder converts types to JSON Schemas, collecting the definitions of the named types they refer to.
+type schemaBuilder struct {
+	definitions apiextensionsv1.JSONSchemaDefinitions
+}
+
+// schemaFor returns the schema for a value of type t, referring to the definition of named types.
+func (b *schemaBuilder) schemaFor(t *types.Type) apiextensionsv1.JSONSchemaProps {
+	if t == nil {
+		return apiextensionsv1.JSONSchemaProps{}
+	}
+
+	if s, ok := wellKnownSchemas[types.Identifier(t)]; ok {
+		return s
+	}
+
+	switch t.Kind {
+	case types.BasicKind:
+		return basicSchema(t.Name)
+	case types.PointerKind:
+		return b.schemaFor(t.UnderlyingType)
+	case types.SliceKind:
+		if t.UnderlyingType != nil && t.UnderlyingType.Kind == types.BasicKind && t.UnderlyingType.Name == "byte" {
+			return apiextensionsv1.JSONSchemaProps{Type: "string", Format: "byte"}
+		}
+		items := b.schemaFor(t.UnderlyingType)
+		return apiextensionsv1.JSONSchemaProps{
+			Type:  "array",
+			Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &items},
+		}
+	case types.MapKind:
+		values := b.schemaFor(t.ValueType)
+		return apiextensionsv1.JSONSchemaProps{
+			Type:                 "object",
+			AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{Allows: true, Schema: &values},
+		}
+	case types.AliasKind, types.StructKind:
+		name := definitionName(t)
+		if _, ok := b.definitions[name]; !ok {
+			// register the name first to stop the recursion on self-referencing types
+			b.definitions[name] = apiextensionsv1.JSONSchemaProps{}
+			b.definitions[name] = b.definition(t)
+		}
+		return apiextensionsv1.JSONSchemaProps{Ref: refTo(name)}
+	default:
+		return apiextensionsv1.JSONSchemaProps{}
+	}
+}
+
+// definition builds the schema describing the named type t.
+func (b *schemaBuilder) definition(t *types.Type) apiextensionsv1.JSONSchemaProps {
+	var schema apiextensionsv1.JSONSchemaProps
+	if t.Kind == types.StructKind {
+		schema = b.structSchema(t)
+	} else {
+		schema = b.schemaFor(t.UnderlyingType)
+		if len(t.EnumValues) > 0 && t.Markers.Get("kubebuilder:validation:Enum") == nil {
+			for _, v := range t.EnumValues {
+				schema.Enum = append(schema.Enum, jsonValue(v.Name))
+			}
+		}
+	}
+
+	schema.Description = t.Doc
+	markerValues := t.Markers
+	if schema.Ref != nil {
+		// $ref siblings are ignored by draft-07 validators, so the reference is wrapped instead
+		schema = apiextensionsv1.JSONSchemaProps{
+			Description: t.Doc,
+			AllOf:       []apiextensionsv1.JSONSchemaProps{{Ref: schema.Ref}},
+			Type:        jsonType(t),
+		}
+		markerValues = ownMarkers(t.Markers, t.UnderlyingType)
+	}
+	applyMarkers(&schema, markerValues, t.String())
+
+	return schema
+}
+
+// structSchema builds the schema of the struct type t from its fields.
+func (b *schemaBuilder) structSchema(t *types.Type) apiextensionsv1.JSONSchemaProps {
+	schema := apiextensionsv1.JSONSchemaProps{
+		Type:        "object",
+		Description: t.Doc,
+		Properties:  make(map[string]apiextensionsv1.JSONSchemaProps),
+	}
+
+	for _, f := range t.Members() {
+		if f.Inlined || f.Name == "" {
+			continue
+		}
+
+		prop := b.schemaFor(f.Type)
+		markerValues := f.Markers
+		if prop.Ref != nil {
+			prop = apiextensionsv1.JSONSchemaProps{
+				AllOf: []apiextensionsv1.JSONSchemaProps{{Ref: prop.Ref}},
+				Type:  jsonType(f.Type),
+			}
+			markerValues = ownMarkers(f.Markers, f.Type)
+		}
+		prop.Description = f.Doc
+		applyMarkers(&prop, markerValues, t.String()+"."+f.Name)
+		schema.Properties[f.Name] = prop
+
+		if f.Required {
+			schema.Required = append(schema.Required, f.Name)
+		}
+	}
+
+	return schema
+}
+
+// ownMarkers returns the markers of markerValues that are not propagated from the type t (see
+// types.TypeMap.PropagateMarkers), as the definition of t referred to with $ref already applies them.
+func ownMarkers(markerValues markers.MarkerValues, t *types.Type) markers.MarkerValues {
+	if t == nil || len(t.Markers) == 0 {
+		return markerValues
+	}
+
+	own := make(markers.MarkerValues, len(markerValues))
+	for name, values := range markerValues {
+		if inherited, ok := t.Markers[name]; ok && reflect.DeepEqual(values, inherited) {
+			continue
+		}
+		own[name] = values
+	}
+	return own
+}
+
+// applyMarkers applies the markers that describe schema properties (e.g. validations) to schema, in the same order
+// as controller-gen does.
+func applyMarkers(schema *apiextensionsv1.JSONSchemaProps, markerValues markers.MarkerValues, target string) {
+	type prioritizedMarker struct {
+		crd.SchemaMarker
+		priority crdmarkers.ApplyPriority
+	}
+
+	var schemaMarkers []prioritizedMarker
+	itemMarkers := make(markers.MarkerValues)
+	for name, values := range markerValues {
+		if itemName, ok := strings.CutPrefix(name, itemsMarkerPrefix); ok {
+			itemMarkers[validationMarkerPrefix+itemName] = values
+			continue
+		}
+
+		for _, v := range values {
+			m, ok := v.(crd.SchemaMarker)
+			if !ok {
+				continue
+			}
+			priority := crdmarkers.ApplyPriorityDefault
+			if pm, ok := v.(crdmarkers.ApplyPriorityMarker); ok {
+				priority = pm.ApplyPriority()
+			}
+			schemaMarkers = append(schemaMarkers, prioritizedMarker{SchemaMarker: m, priority: priority})
+		}
+	}
+
+	// kubebuilder:validation:items:* markers apply to the items of a list
+	if len(itemMarkers) > 0 && schema.Items != nil && schema.Items.Schema != nil {
+		applyMarkers(schema.Items.Schema, itemMarkers, target+"[]")
+	}
+
+	sort.SliceStable(schemaMarkers, func(i, j int) bool {
+		return schemaMarkers[i].priority < schemaMarkers[j].priority
+	})
+
+	for _, m := range schemaMarkers {
+		if err := m.ApplyToSchema(schema); err != nil {
+			zap.S().Debugw("Failed to apply marker to schema", "target", target, "error", err)
+		}
+	}
+}
+
+// jsonType returns the JSON type of values of type t, or an empty string if it cannot be determined.
+func jsonType(t *types.Type) string {
+	if t == nil {
+		return ""
+	}
+
+	if s, ok := wellKnownSchemas[types.Identifier(t)]; ok {
+		return s.Type
+	}
+
+	switch t.Kind {
+	case types.BasicKind:
+		return basicSchema(t.Name).Type
+	case types.AliasKind, types.PointerKind:
+		return jsonType(t.UnderlyingType)
+	case types.SliceKind:
+		return "array"
+	case types.MapKind, types.StructKind:
+		return "object"
+	default:
+		return ""
+	}
+}
+
+func basicSchema(name string) apiextensionsv1.JSONSchemaProps {
+	switch name {
+	case "int32":
+		return apiextensionsv1.JSONSchemaProps{Type: "integer", Format: "int32"}
+	case "int64":
+		return apiextensionsv1.JSONSchemaProps{Type: "integer", Format: "int64"}
+	case "int", "int8", "int16", "uint", "uint8", "uint16", "uint32", "uint64", "byte", "rune":
+		return apiextensionsv1.JSONSchemaProps{Type: "integer"}
+	case "float32", "float64":
+		return apiextensionsv1.JSONSchemaProps{Type: "number"}
+	case "bool":
+		return apiextensionsv1.JSONSchemaProps{Type: "boolean"}
+	case "string":
+		return apiextensionsv1.JSONSchemaProps{Type: "string"}
+	default:
+		return apiextensionsv1.JSONSchemaProps{}
+	}
+}
+
+// definitionName returns the key of the definition of t. Slashes are replaced so that the name can be used in a
+// JSON pointer as is.
+func definitionName(t *types.Type) string {
+	return strings.ReplaceAll(types.Identifier(t), "/", ".")
+}
+
+func refTo(name string) *string {
+	ref := "#/definitions/" + name
+	return &ref
+}
+
+func jsonValue(v any) apiextensionsv1.JSON {
+	raw, _ := json.Marshal(v)
+	return apiextensionsv1.JSON{Raw: raw}
+}
@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	crdmarkers "sigs.k8s.io/controller-tools/pkg/crd/markers"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+)
+
+func TestJSONSchemaRenderer_SchemaForKind(t *testing.T) {
+	r, err := NewJSONSchemaRenderer(&config.Config{})
+	require.NoError(t, err)
+
+	str := &types.Type{Name: "string", Kind: types.BasicKind}
+	name := &types.Type{
+		Name:           "Name",
+		Package:        "example.com/api/v1",
+		Kind:           types.AliasKind,
+		Doc:            "Name of a book.",
+		UnderlyingType: str,
+		Markers:        markers.MarkerValues{"kubebuilder:validation:MaxLength": {crdmarkers.MaxLength(10)}},
+	}
+	book := &types.Type{
+		Name:    "Book",
+		Package: "example.com/api/v1",
+		Kind:    types.StructKind,
+		GVK:     &schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Book"},
+		Fields: types.Fields{
+			{
+				Name:     "name",
+				Doc:      "Name of the book.",
+				Type:     name,
+				Required: true,
+				// MaxLength is propagated from the Name type
+				Markers: markers.MarkerValues{
+					"kubebuilder:validation:MaxLength": {crdmarkers.MaxLength(10)},
+					"kubebuilder:validation:MinLength": {crdmarkers.MinLength(1)},
+				},
+			},
+			{
+				Name: "tags",
+				Type: &types.Type{Kind: types.SliceKind, UnderlyingType: str},
+				Markers: markers.MarkerValues{
+					"kubebuilder:validation:MaxItems":      {crdmarkers.MaxItems(5)},
+					"kubebuilder:validation:items:Pattern": {crdmarkers.Pattern("[a-z]+")},
+					"listType":                             {crdmarkers.ListType("set")},
+				},
+			},
+		},
+	}
+	gvd := types.GroupVersionDetails{GroupVersion: schema.GroupVersion{Group: "example.com", Version: "v1"}}
+
+	actual, err := json.Marshal(r.SchemaForKind(gvd, book))
+	require.NoError(t, err)
+
+	expected := `{
+	  "$schema": "http://json-schema.org/draft-07/schema#",
+	  "title": "Book",
+	  "type": "object",
+	  "required": ["name"],
+	  "properties": {
+	    "apiVersion": {"type": "string", "enum": ["example.com/v1"]},
+	    "kind": {"type": "string", "enum": ["Book"]},
+	    "name": {
+	      "description": "Name of the book.",
+	      "type": "string",
+	      "minLength": 1,
+	      "allOf": [{"$ref": "#/definitions/example.com.api.v1.Name"}]
+	    },
+	    "tags": {
+	      "type": "array",
+	      "maxItems": 5,
+	      "x-kubernetes-list-type": "set",
+	      "items": {"type": "string", "pattern": "[a-z]+"}
+	    }
+	  },
+	  "definitions": {
+	    "example.com.api.v1.Name": {"description": "Name of a book.", "type": "string", "maxLength": 10}
+	  }
+	}`
+	require.JSONEq(t, expected, string(actual))
+}
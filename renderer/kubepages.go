@@ -0,0 +1,154 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import "strings"
+
+const (
+	kubeSinglePageLinkTemplate  = `https://kubernetes.io/docs/reference/generated/kubernetes-api/v{{ .kubeVersion }}/#{{ .type }}-{{ .version }}-{{ .group }}`
+	kubePerResourceLinkTemplate = `https://kubernetes.io/docs/reference/kubernetes-api/{{ with .page }}{{ . }}/{{ end }}{{ with .anchor }}#{{ . }}{{ end }}`
+)
+
+// kubeResourcePage locates the documentation of a type in the per-resource Kubernetes API reference.
+type kubeResourcePage struct {
+	page   string
+	anchor string
+	// singlePage is set for the types that have no page in the per-resource reference, which link to the single-page
+	// reference instead.
+	singlePage bool
+}
+
+// kubeResourcePages maps "<group>/<version>.<name>" to the page of the per-resource Kubernetes API reference
+// documenting the type. The group is the name of the parent directory of the package, as for the single-page links.
+var kubeResourcePages = map[string]kubeResourcePage{
+	// Workload resources
+	"core/v1.Pod":                            {page: "workload-resources/pod-v1"},
+	"core/v1.Container":                      {page: "workload-resources/pod-v1", anchor: "Container"},
+	"core/v1.EphemeralContainer":             {page: "workload-resources/pod-v1", anchor: "EphemeralContainer"},
+	"core/v1.PodTemplate":                    {page: "workload-resources/pod-template-v1"},
+	"core/v1.ReplicationController":          {page: "workload-resources/replication-controller-v1"},
+	"apps/v1.ReplicaSet":                     {page: "workload-resources/replica-set-v1"},
+	"apps/v1.Deployment":                     {page: "workload-resources/deployment-v1"},
+	"apps/v1.StatefulSet":                    {page: "workload-resources/stateful-set-v1"},
+	"apps/v1.ControllerRevision":             {page: "workload-resources/controller-revision-v1"},
+	"apps/v1.DaemonSet":                      {page: "workload-resources/daemon-set-v1"},
+	"batch/v1.Job":                           {page: "workload-resources/job-v1"},
+	"batch/v1.CronJob":                       {page: "workload-resources/cron-job-v1"},
+	"autoscaling/v1.HorizontalPodAutoscaler": {page: "workload-resources/horizontal-pod-autoscaler-v1"},
+	"autoscaling/v2.HorizontalPodAutoscaler": {page: "workload-resources/horizontal-pod-autoscaler-v2"},
+	"scheduling/v1.PriorityClass":            {page: "workload-resources/priority-class-v1"},
+
+	// Service resources
+	"core/v1.Service":            {page: "service-resources/service-v1"},
+	"core/v1.Endpoints":          {page: "service-resources/endpoints-v1"},
+	"discovery/v1.EndpointSlice": {page: "service-resources/endpoint-slice-v1"},
+	"networking/v1.Ingress":      {page: "service-resources/ingress-v1"},
+	"networking/v1.IngressClass": {page: "service-resources/ingress-class-v1"},
+
+	// Config and storage resources
+	"core/v1.ConfigMap":             {page: "config-and-storage-resources/config-map-v1"},
+	"core/v1.Secret":                {page: "config-and-storage-resources/secret-v1"},
+	"core/v1.Volume":                {page: "config-and-storage-resources/volume"},
+	"core/v1.PersistentVolumeClaim": {page: "config-and-storage-resources/persistent-volume-claim-v1"},
+	"core/v1.PersistentVolume":      {page: "config-and-storage-resources/persistent-volume-v1"},
+	"storage/v1.StorageClass":       {page: "config-and-storage-resources/storage-class-v1"},
+	"storage/v1.VolumeAttachment":   {page: "config-and-storage-resources/volume-attachment-v1"},
+	"storage/v1.CSIDriver":          {page: "config-and-storage-resources/csi-driver-v1"},
+	"storage/v1.CSINode":            {page: "config-and-storage-resources/csi-node-v1"},
+	"storage/v1.CSIStorageCapacity": {page: "config-and-storage-resources/csi-storage-capacity-v1"},
+
+	// Authentication and authorization resources
+	"core/v1.ServiceAccount":                    {page: "authentication-resources/service-account-v1"},
+	"authentication/v1.TokenRequest":            {page: "authentication-resources/token-request-v1"},
+	"authentication/v1.TokenReview":             {page: "authentication-resources/token-review-v1"},
+	"certificates/v1.CertificateSigningRequest": {page: "authentication-resources/certificate-signing-request-v1"},
+	"authorization/v1.LocalSubjectAccessReview": {page: "authorization-resources/local-subject-access-review-v1"},
+	"authorization/v1.SelfSubjectAccessReview":  {page: "authorization-resources/self-subject-access-review-v1"},
+	"authorization/v1.SelfSubjectRulesReview":   {page: "authorization-resources/self-subject-rules-review-v1"},
+	"authorization/v1.SubjectAccessReview":      {page: "authorization-resources/subject-access-review-v1"},
+	"rbac/v1.ClusterRole":                       {page: "authorization-resources/cluster-role-v1"},
+	"rbac/v1.ClusterRoleBinding":                {page: "authorization-resources/cluster-role-binding-v1"},
+	"rbac/v1.Role":                              {page: "authorization-resources/role-v1"},
+	"rbac/v1.RoleBinding":                       {page: "authorization-resources/role-binding-v1"},
+
+	// Policy resources
+	"core/v1.LimitRange":            {page: "policy-resources/limit-range-v1"},
+	"core/v1.ResourceQuota":         {page: "policy-resources/resource-quota-v1"},
+	"networking/v1.NetworkPolicy":   {page: "policy-resources/network-policy-v1"},
+	"policy/v1.PodDisruptionBudget": {page: "policy-resources/pod-disruption-budget-v1"},
+
+	// Extend resources
+	"apiextensions/v1.CustomResourceDefinition":               {page: "extend-resources/custom-resource-definition-v1"},
+	"admissionregistration/v1.MutatingWebhookConfiguration":   {page: "extend-resources/mutating-webhook-configuration-v1"},
+	"admissionregistration/v1.ValidatingWebhookConfiguration": {page: "extend-resources/validating-webhook-configuration-v1"},
+
+	// Cluster resources
+	"core/v1.Node":          {page: "cluster-resources/node-v1"},
+	"core/v1.Namespace":     {page: "cluster-resources/namespace-v1"},
+	"events/v1.Event":       {page: "cluster-resources/event-v1"},
+	"coordination/v1.Lease": {page: "cluster-resources/lease-v1"},
+	"node/v1.RuntimeClass":  {page: "cluster-resources/runtime-class-v1"},
+
+	// Common definitions
+	"meta/v1.DeleteOptions":             {page: "common-definitions/delete-options"},
+	"meta/v1.LabelSelector":             {page: "common-definitions/label-selector"},
+	"meta/v1.ListMeta":                  {page: "common-definitions/list-meta"},
+	"meta/v1.ObjectMeta":                {page: "common-definitions/object-meta"},
+	"meta/v1.Status":                    {page: "common-definitions/status"},
+	"core/v1.LocalObjectReference":      {page: "common-definitions/local-object-reference"},
+	"core/v1.NodeSelectorRequirement":   {page: "common-definitions/node-selector-requirement"},
+	"core/v1.ObjectFieldSelector":       {page: "common-definitions/object-field-selector"},
+	"core/v1.ObjectReference":           {page: "common-definitions/object-reference"},
+	"core/v1.ResourceFieldSelector":     {page: "common-definitions/resource-field-selector"},
+	"core/v1.TypedLocalObjectReference": {page: "common-definitions/typed-local-object-reference"},
+	"api/resource.Quantity":             {page: "common-definitions/quantity"},
+
+	// Types described inline in the per-resource reference
+	"meta/v1.Condition":        {singlePage: true},
+	"meta/v1.Duration":         {singlePage: true},
+	"meta/v1.MicroTime":        {singlePage: true},
+	"meta/v1.Time":             {singlePage: true},
+	"meta/v1.TypeMeta":         {singlePage: true},
+	"apiextensions/v1.JSON":    {singlePage: true},
+	"pkg/runtime.RawExtension": {singlePage: true},
+	"util/intstr.IntOrString":  {singlePage: true},
+}
+
+// kubeResourcePageSuffixes are the suffixes of the types documented on the page of their resource, e.g. PodSpec on
+// the page of Pod.
+var kubeResourcePageSuffixes = []string{"Spec", "Status", "List", "Condition"}
+
+// lookupKubeResourcePage returns the location of the documentation of the type name of the given group and version
+// in the per-resource Kubernetes API reference. The types that are not found have no page in the reference.
+func lookupKubeResourcePage(group, version, name string) (kubeResourcePage, bool) {
+	prefix := group + "/" + version + "."
+	if page, ok := kubeResourcePages[prefix+name]; ok {
+		return page, true
+	}
+
+	for _, suffix := range kubeResourcePageSuffixes {
+		resource, ok := strings.CutSuffix(name, suffix)
+		if !ok || resource == "" {
+			continue
+		}
+		if page, ok := kubeResourcePages[prefix+resource]; ok && page.anchor == "" && !page.singlePage {
+			return kubeResourcePage{page: page.page, anchor: name}, true
+		}
+	}
+
+	return kubeResourcePage{}, false
+}
@@ -0,0 +1,347 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"go.uber.org/zap"
+)
+
+const externalLinkTimeout = 10 * time.Second
+
+var (
+	urlSchemeRegex            = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+	markdownHeadingRegex      = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*\s*$`)
+	markdownAnchorRegex       = regexp.MustCompile(`<a\s+(?:id|name)="([^"]+)"`)
+	markdownLinkRegex         = regexp.MustCompile(`\[[^\]]*\]\(([^)\s]+)\)`)
+	asciidocAttributeRegex    = regexp.MustCompile(`^:([\w-]+):\s*(.*)$`)
+	asciidocAnchorRegex       = regexp.MustCompile(`\[id="([^"]+)"\]|\[\[([^\],]+)(?:,[^\]]*)?\]\]|\[#([^\].%,]+)`)
+	asciidocLinkRegex         = regexp.MustCompile(`xref:([^\[\s]+)\[|<<([^,>]+)(?:,[^>]*)?>>|link:([^\[\s]+)\[`)
+	asciidocAttributeRefRegex = regexp.MustCompile(`\{([\w-]+)\}`)
+)
+
+// BrokenLink is a link of the rendered output that cannot be resolved.
+type BrokenLink struct {
+	File   string
+	Target string
+	Reason string
+}
+
+func (l BrokenLink) String() string {
+	return fmt.Sprintf("%s: %s: %s", l.File, l.Target, l.Reason)
+}
+
+// linkSyntax extracts the anchors and link targets from a rendered file.
+type linkSyntax interface {
+	anchors(content []byte) []string
+	links(content []byte) []string
+}
+
+// linkChecker reports the local links of the rendered files that do not point to an emitted anchor and, optionally,
+// the external links that cannot be fetched.
+type linkChecker struct {
+	syntax   linkSyntax
+	external bool
+	client   config.HTTPClient
+}
+
+func newLinkChecker(conf *config.LinkCheckConfig, syntax linkSyntax) *linkChecker {
+	client := conf.Client
+	if client == nil {
+		client = &http.Client{Timeout: externalLinkTimeout}
+	}
+
+	return &linkChecker{
+		syntax:   syntax,
+		external: conf.External,
+		client:   client,
+	}
+}
+
+// check returns the broken links found in files, sorted by file. Each target is reported once per file.
+func (c *linkChecker) check(ctx context.Context, files []outputFile) []BrokenLink {
+	anchors := make(map[string]map[string]struct{}, len(files))
+	for _, f := range files {
+		anchors[f.name] = make(map[string]struct{})
+		for _, a := range c.syntax.anchors(f.content) {
+			anchors[f.name][a] = struct{}{}
+		}
+	}
+
+	var broken []BrokenLink
+	externalStatus := make(map[string]string)
+	for _, f := range files {
+		seen := make(map[string]struct{})
+		for _, target := range c.syntax.links(f.content) {
+			if _, ok := seen[target]; ok {
+				continue
+			}
+			seen[target] = struct{}{}
+
+			if isExternalLink(target) {
+				if !c.external || !isHTTPLink(target) {
+					continue
+				}
+				reason, checked := externalStatus[target]
+				if !checked {
+					reason = c.checkExternal(ctx, target)
+					externalStatus[target] = reason
+				}
+				if reason != "" {
+					broken = append(broken, BrokenLink{File: f.name, Target: target, Reason: reason})
+				}
+				continue
+			}
+
+			if reason := resolveLocalLink(anchors, f.name, target); reason != "" {
+				broken = append(broken, BrokenLink{File: f.name, Target: target, Reason: reason})
+			}
+		}
+	}
+
+	sort.SliceStable(broken, func(i, j int) bool { return broken[i].File < broken[j].File })
+	return broken
+}
+
+// resolveLocalLink returns the reason why target, linked from file, cannot be resolved, or an empty string.
+func resolveLocalLink(anchors map[string]map[string]struct{}, file, target string) string {
+	targetFile, anchor, hasFile := strings.Cut(target, "#")
+	if !hasFile {
+		// Asciidoctor cross references name the anchor directly.
+		targetFile, anchor = "", target
+	}
+	if targetFile == "" {
+		targetFile = file
+	}
+
+	fileAnchors, ok := anchors[targetFile]
+	if !ok {
+		return "file not found in the output"
+	}
+	if anchor == "" {
+		return ""
+	}
+	if _, ok := fileAnchors[anchor]; !ok {
+		return "anchor not found"
+	}
+
+	return ""
+}
+
+// checkExternal returns the reason why url cannot be fetched, or an empty string. Servers that do not support HEAD
+// requests, answering with 405 Method Not Allowed or 403 Forbidden, are checked with a GET request instead.
+func (c *linkChecker) checkExternal(ctx context.Context, url string) string {
+	status, err := c.fetch(ctx, http.MethodHead, url)
+	if err == nil && (status.code == http.StatusMethodNotAllowed || status.code == http.StatusForbidden) {
+		status, err = c.fetch(ctx, http.MethodGet, url)
+	}
+	if err != nil {
+		return err.Error()
+	}
+
+	if status.code >= http.StatusBadRequest {
+		return status.text
+	}
+
+	return ""
+}
+
+type httpStatus struct {
+	code int
+	text string
+}
+
+func (c *linkChecker) fetch(ctx context.Context, method, url string) (httpStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return httpStatus{}, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return httpStatus{}, err
+	}
+	resp.Body.Close()
+
+	return httpStatus{code: resp.StatusCode, text: resp.Status}, nil
+}
+
+// isExternalLink reports whether target is an absolute URL. Only HTTP(S) links are checked.
+func isExternalLink(target string) bool {
+	return urlSchemeRegex.MatchString(target)
+}
+
+func isHTTPLink(target string) bool {
+	return strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://")
+}
+
+// checkLinks runs the link checker on files, if enabled. Broken links are logged, and reported as an error if
+// configured.
+func checkLinks(conf *config.Config, syntax linkSyntax, files []outputFile) error {
+	if conf.Render.LinkCheck == nil {
+		return nil
+	}
+
+	broken := newLinkChecker(conf.Render.LinkCheck, syntax).check(context.Background(), files)
+	for _, l := range broken {
+		zap.S().Warnw("Broken link", "file", l.File, "target", l.Target, "reason", l.Reason)
+	}
+
+	if len(broken) > 0 && conf.Render.LinkCheck.Fail {
+		return fmt.Errorf("found %d broken links", len(broken))
+	}
+
+	return nil
+}
+
+// markdownLinkSyntax extracts the heading anchors and links of Markdown files, using the anchor format of headings.
+type markdownLinkSyntax struct {
+	slug func(text string) string
+}
+
+func (s markdownLinkSyntax) anchors(content []byte) []string {
+	var anchors []string
+	eachMarkdownLine(content, func(line string) {
+		if m := markdownHeadingRegex.FindStringSubmatch(line); m != nil {
+			anchors = append(anchors, s.slug(m[1]))
+		}
+		for _, m := range markdownAnchorRegex.FindAllStringSubmatch(line, -1) {
+			anchors = append(anchors, m[1])
+		}
+	})
+
+	return anchors
+}
+
+func (s markdownLinkSyntax) links(content []byte) []string {
+	var links []string
+	eachMarkdownLine(content, func(line string) {
+		for _, m := range markdownLinkRegex.FindAllStringSubmatch(line, -1) {
+			links = append(links, m[1])
+		}
+	})
+
+	return links
+}
+
+// eachMarkdownLine calls fn with every line of content that is not part of a fenced code block.
+func eachMarkdownLine(content []byte, fn func(line string)) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	inCode := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			continue
+		}
+		if !inCode {
+			fn(line)
+		}
+	}
+}
+
+// asciidocLinkSyntax extracts the anchors and links of AsciiDoc files, substituting the document attributes.
+type asciidocLinkSyntax struct{}
+
+func (asciidocLinkSyntax) anchors(content []byte) []string {
+	var anchors []string
+	eachAsciidocLine(content, func(line string) {
+		for _, m := range asciidocAnchorRegex.FindAllStringSubmatch(line, -1) {
+			anchors = append(anchors, firstNonEmpty(m[1:]))
+		}
+	})
+
+	return anchors
+}
+
+func (asciidocLinkSyntax) links(content []byte) []string {
+	var links []string
+	eachAsciidocLine(content, func(line string) {
+		for _, m := range asciidocLinkRegex.FindAllStringSubmatch(line, -1) {
+			links = append(links, firstNonEmpty(m[1:]))
+		}
+	})
+
+	return links
+}
+
+// eachAsciidocLine calls fn with every line of content that is not part of a listing block, after substituting the
+// attributes defined in the document.
+func eachAsciidocLine(content []byte, fn func(line string)) {
+	attrs := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	inListing := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "----" || line == "...." {
+			inListing = !inListing
+			continue
+		}
+		if inListing {
+			continue
+		}
+
+		if m := asciidocAttributeRegex.FindStringSubmatch(line); m != nil {
+			attrs[m[1]] = m[2]
+			continue
+		}
+
+		fn(substituteAsciidocAttributes(attrs, line))
+	}
+}
+
+// asciidocAttributes returns the attributes defined in content.
+func asciidocAttributes(content []byte) map[string]string {
+	attrs := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if m := asciidocAttributeRegex.FindStringSubmatch(scanner.Text()); m != nil {
+			attrs[m[1]] = m[2]
+		}
+	}
+	return attrs
+}
+
+// substituteAsciidocAttributes replaces the references to the given attributes in text, e.g. {anchor_prefix}.
+func substituteAsciidocAttributes(attrs map[string]string, text string) string {
+	return asciidocAttributeRefRegex.ReplaceAllStringFunc(text, func(ref string) string {
+		if v, ok := attrs[ref[1:len(ref)-1]]; ok {
+			return v
+		}
+		return ref
+	})
+}
+
+func firstNonEmpty(values []string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHTTPClient maps URLs, optionally prefixed with a method (e.g. "GET https://example.com"), to status codes.
+type fakeHTTPClient map[string]int
+
+func (c fakeHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	status, ok := c[req.Method+" "+req.URL.String()]
+	if !ok {
+		status, ok = c[req.URL.String()]
+	}
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+
+	return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestLinkCheckerMarkdown(t *testing.T) {
+	files := []outputFile{
+		{name: "a.md", content: []byte(`## Packages
+- [a.example.com/v1](#aexamplecomv1)
+
+#### Guestbook
+- [GuestbookSpec](#guestbookspec)
+- [Guestbook](#guestbook)
+- [Other](b.md#other)
+- [Missing](b.md#missing)
+- [Elsewhere](c.md#other)
+- [Docs](https://example.com/ok)
+- [Docs](https://example.com/missing)
+- [Docs](docs-content://new/page.md)
+
+` + "```" + `
+[Ignored](#ignored)
+` + "```" + `
+`)},
+		{name: "b.md", content: []byte(`#### Other
+<a id="custom"></a>
+- [Custom](#custom)
+- [GuestbookSpec](#guestbookspec)
+`)},
+	}
+
+	client := fakeHTTPClient{"https://example.com/ok": http.StatusOK, "https://example.com/missing": http.StatusNotFound}
+	checker := newLinkChecker(&config.LinkCheckConfig{External: true, Client: client}, markdownLinkSyntax{slug: legacySlug})
+
+	require.Equal(t, []BrokenLink{
+		{File: "a.md", Target: "#aexamplecomv1", Reason: "anchor not found"},
+		{File: "a.md", Target: "#guestbookspec", Reason: "anchor not found"},
+		{File: "a.md", Target: "b.md#missing", Reason: "anchor not found"},
+		{File: "a.md", Target: "c.md#other", Reason: "file not found in the output"},
+		{File: "a.md", Target: "https://example.com/missing", Reason: "Not Found"},
+		{File: "b.md", Target: "#guestbookspec", Reason: "anchor not found"},
+	}, checker.check(context.Background(), files))
+
+	checker.external = false
+	require.Len(t, checker.check(context.Background(), files), 5)
+}
+
+func TestLinkCheckerAsciidoc(t *testing.T) {
+	files := []outputFile{
+		{name: "out.asciidoc", content: []byte(`:anchor_prefix: k8s-api
+
+[id="{anchor_prefix}-guestbook"]
+==== Guestbook
+
+[[spec]]
+- xref:{anchor_prefix}-guestbook[$$Guestbook$$]
+- xref:{anchor_prefix}-guestbookstatus[$$GuestbookStatus$$]
+- <<spec,Spec>>
+- <<status>>
+- link:https://example.com/down[Docs]
+
+----
+xref:{anchor_prefix}-ignored[$$Ignored$$]
+----
+`)},
+	}
+
+	checker := newLinkChecker(&config.LinkCheckConfig{External: true, Client: fakeHTTPClient{}}, asciidocLinkSyntax{})
+
+	require.Equal(t, []BrokenLink{
+		{File: "out.asciidoc", Target: "k8s-api-guestbookstatus", Reason: "anchor not found"},
+		{File: "out.asciidoc", Target: "status", Reason: "anchor not found"},
+		{File: "out.asciidoc", Target: "https://example.com/down", Reason: "connection refused"},
+	}, checker.check(context.Background(), files))
+}
+
+func TestCheckLinks(t *testing.T) {
+	files := []outputFile{{name: "out.md", content: []byte("- [Missing](#missing)\n")}}
+
+	require.NoError(t, checkLinks(&config.Config{}, markdownLinkSyntax{slug: legacySlug}, files))
+	require.NoError(t, checkLinks(&config.Config{Render: config.RenderConfig{LinkCheck: &config.LinkCheckConfig{}}}, markdownLinkSyntax{slug: legacySlug}, files))
+	require.EqualError(t, checkLinks(&config.Config{Render: config.RenderConfig{LinkCheck: &config.LinkCheckConfig{Fail: true}}}, markdownLinkSyntax{slug: legacySlug}, files), "found 1 broken links")
+}
+
+func TestCheckExternal(t *testing.T) {
+	client := fakeHTTPClient{
+		"https://example.com/ok":                  http.StatusOK,
+		"HEAD https://example.com/no-head":        http.StatusMethodNotAllowed,
+		"GET https://example.com/no-head":         http.StatusOK,
+		"HEAD https://example.com/forbidden-head": http.StatusForbidden,
+		"GET https://example.com/forbidden-head":  http.StatusOK,
+		"https://example.com/forbidden":           http.StatusForbidden,
+		"HEAD https://example.com/missing":        http.StatusMethodNotAllowed,
+		"GET https://example.com/missing":         http.StatusNotFound,
+	}
+	checker := newLinkChecker(&config.LinkCheckConfig{External: true, Client: client}, asciidocLinkSyntax{})
+
+	ctx := context.Background()
+	require.Equal(t, "", checker.checkExternal(ctx, "https://example.com/ok"))
+	require.Equal(t, "", checker.checkExternal(ctx, "https://example.com/no-head"))
+	require.Equal(t, "", checker.checkExternal(ctx, "https://example.com/forbidden-head"))
+	require.Equal(t, "Forbidden", checker.checkExternal(ctx, "https://example.com/forbidden"))
+	require.Equal(t, "Not Found", checker.checkExternal(ctx, "https://example.com/missing"))
+	require.Equal(t, "connection refused", checker.checkExternal(ctx, "https://example.com/down"))
+}
+
+func TestNewLinkCheckerDefaultClient(t *testing.T) {
+	checker := newLinkChecker(&config.LinkCheckConfig{}, asciidocLinkSyntax{})
+	require.Equal(t, &http.Client{Timeout: externalLinkTimeout}, checker.client)
+}
@@ -18,6 +18,8 @@ package renderer
 
 import (
 	"fmt"
+	"html"
+	"io"
 	"io/fs"
 	"os"
 	"slices"
@@ -33,6 +35,7 @@ import (
 type MarkdownRenderer struct {
 	conf *config.Config
 	*Functions
+	site *siteIntegration
 }
 
 func NewMarkdownRenderer(conf *config.Config) (*MarkdownRenderer, error) {
@@ -40,46 +43,116 @@ func NewMarkdownRenderer(conf *config.Config) (*MarkdownRenderer, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &MarkdownRenderer{conf: conf, Functions: baseFuncs}, nil
+	site, err := newSiteIntegration(conf)
+	if err != nil {
+		return nil, err
+	}
+	return &MarkdownRenderer{conf: conf, Functions: baseFuncs, site: site}, nil
 }
 
 func (m *MarkdownRenderer) Render(gvd []types.GroupVersionDetails) error {
-	funcMap := combinedFuncMap(funcMap{prefix: "markdown", funcs: m.ToFuncMap()}, funcMap{funcs: sprig.TxtFuncMap()})
+	files, err := m.renderFiles(gvd)
+	if err != nil {
+		return err
+	}
 
-	var tpls fs.FS
-	if m.conf.TemplatesDir != "" {
-		tpls = os.DirFS(m.conf.TemplatesDir)
-	} else {
-		sub, err := fs.Sub(templates.Root, "markdown")
-		if err != nil {
-			return err
-		}
-		tpls = sub
+	if err := writeFiles(m.conf, files); err != nil {
+		return err
 	}
 
-	tmpl, err := loadTemplate(tpls, funcMap)
+	return writeInventory(m.conf, files, m)
+}
+
+func (m *MarkdownRenderer) RenderTo(w io.Writer, gvd []types.GroupVersionDetails) error {
+	files, err := m.renderFiles(gvd)
 	if err != nil {
 		return err
 	}
 
-	return renderTemplate(tmpl, m.conf, "md", gvd)
+	return writeOutput(w, m.conf.OutputMode == config.OutputModeGroup, files)
+}
+
+// documentedTypes returns the types of gvd rendered with a heading by the templates.
+func (m *MarkdownRenderer) documentedTypes(gvd types.GroupVersionDetails) []*types.Type {
+	return m.renderedTypes(gvd, m.ShouldRenderType)
+}
+
+// anchor returns the anchor of the heading documenting t.
+func (m *MarkdownRenderer) anchor(_ outputFile, t *types.Type) string {
+	return m.site.slug(m.SimplifiedTypeName(t))
+}
+
+func (m *MarkdownRenderer) renderFiles(gvd []types.GroupVersionDetails) ([]outputFile, error) {
+	m.indexTypes(gvd)
+	tmpl, err := m.loadTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := executeTemplate(tmpl, m.conf, "md", gvd)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err = m.site.integrate(files, m.conf.OutputMode)
+	if err != nil {
+		return nil, err
+	}
+
+	return files, checkLinks(m.conf, markdownLinkSyntax{slug: m.site.slug}, files)
+}
+
+func (m *MarkdownRenderer) loadTemplate() (*template.Template, error) {
+	funcMap := combinedFuncMap(funcMap{prefix: "markdown", funcs: m.ToFuncMap()}, funcMap{funcs: sprig.TxtFuncMap()})
+
+	defaults, err := fs.Sub(templates.Root, "markdown")
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides fs.FS
+	if m.conf.TemplatesDir != "" {
+		overrides = os.DirFS(m.conf.TemplatesDir)
+	}
+
+	return loadTemplate(defaults, overrides, funcMap)
 }
 
 func (m *MarkdownRenderer) ToFuncMap() template.FuncMap {
 	return template.FuncMap{
-		"GroupVersionID":     m.GroupVersionID,
-		"RenderExternalLink": m.RenderExternalLink,
-		"RenderGVLink":       m.RenderGVLink,
-		"RenderLocalLink":    m.RenderLocalLink,
-		"RenderType":         m.RenderType,
-		"RenderTypeLink":     m.RenderTypeLink,
-		"RewriteLinks":       m.RewriteLinks,
-		"SafeID":             m.SafeID,
-		"ShouldRenderType":   m.ShouldRenderType,
-		"TypeID":             m.TypeID,
-		"RenderFieldDoc":     m.RenderFieldDoc,
-		"RenderDefault":      m.RenderDefault,
-		"TemplateValue":      m.TemplateValue,
+		"FieldNotes":            m.FieldNotes,
+		"FieldOrder":            m.FieldOrder,
+		"GroupVersionID":        m.GroupVersionID,
+		"ExampleYAML":           m.ExampleYAML,
+		"IsBlockValue":          m.IsBlockValue,
+		"IsCollapsed":           m.IsCollapsed,
+		"KubeTypes":             m.KubeTypes,
+		"ObservedStateMode":     m.ObservedStateMode,
+		"ObservedStateSections": m.ObservedStateSections,
+		"PackageGroups":         types.GroupPackages,
+		"RenderDOTDiagram":      m.DOTDiagram,
+		"RenderExternalLink":    m.RenderExternalLink,
+		"RenderGVLink":          m.RenderGVLink,
+		"RenderLocalLink":       m.RenderLocalLink,
+		"RenderMermaidDiagram":  m.MermaidDiagram,
+		"RenderType":            m.RenderType,
+		"RenderTypeLink":        m.RenderTypeLink,
+		"RewriteLinks":          m.RewriteLinks,
+		"SafeID":                m.SafeID,
+		"ShouldRenderField":     m.ShouldRenderField,
+		"ShouldRenderType":      m.ShouldRenderType,
+		"ShowTypeDiagrams":      m.ShowTypeDiagrams,
+		"TypeID":                m.TypeID,
+		"TypeOrder":             m.TypeOrder,
+		"RenderFieldDoc":        m.RenderFieldDoc,
+		"RenderValidation":      m.RenderValidation,
+		"RenderValidations":     m.RenderValidations,
+		"RenderDefault":         m.RenderDefault,
+		"RenderDefaultValue":    m.RenderDefaultValue,
+		"RenderExample":         m.RenderExample,
+		"RenderDoc":             m.RenderDoc,
+		"RenderDocHTML":         m.DocToHTML,
+		"TemplateValue":         m.TemplateValue,
 	}
 }
 
@@ -123,16 +196,7 @@ func (m *MarkdownRenderer) RenderTypeLink(t *types.Type) string {
 }
 
 func (m *MarkdownRenderer) RenderLocalLink(text string) string {
-	anchor := strings.ToLower(
-		strings.NewReplacer(
-			" ", "-",
-			".", "",
-			"/", "",
-			"(", "",
-			")", "",
-		).Replace(text),
-	)
-	return fmt.Sprintf("[%s](#%s)", text, anchor)
+	return fmt.Sprintf("[%s](#%s)", text, m.site.slug(text))
 }
 
 func (m *MarkdownRenderer) TemplateValue(key string) string {
@@ -147,7 +211,7 @@ func (m *MarkdownRenderer) RenderExternalLink(link, text string) string {
 }
 
 func (m *MarkdownRenderer) RenderGVLink(gv types.GroupVersionDetails) string {
-	return m.RenderLocalLink(gv.GroupVersionString())
+	return m.RenderLocalLink(gv.DisplayName())
 }
 
 func (m *MarkdownRenderer) RewriteLinks(text string) string {
@@ -167,9 +231,58 @@ func (m *MarkdownRenderer) RewriteLinks(text string) string {
 	return text
 }
 
+// RenderDoc renders the doc string of a type or group version.
+func (m *MarkdownRenderer) RenderDoc(text string) string {
+	if m.parseDocComments() {
+		return m.DocToMarkdown(text, m.localLinkURL)
+	}
+
+	return m.RewriteLinks(text)
+}
+
+func (m *MarkdownRenderer) localLinkURL(t *types.Type) string {
+	return "#" + m.site.slug(m.SimplifiedTypeName(t))
+}
+
 func (m *MarkdownRenderer) RenderFieldDoc(text string) string {
-	out := m.RewriteLinks(text)
+	var out string
+	if m.parseDocComments() {
+		out = m.DocToMarkdownCell(text, m.localLinkURL)
+	} else {
+		out = m.RewriteLinks(text)
+	}
+	return escapeTableCell(out)
+}
+
+// RenderValidation renders a validation rule in a table cell. Unlike RenderFieldDoc, the text is not
+// interpreted as a doc comment.
+func (m *MarkdownRenderer) RenderValidation(text string) string {
+	return escapeTableCell(m.RewriteLinks(text))
+}
 
+// RenderValidations describes the validation rules in prose, e.g. "1 ≤ value ≤ 5", for a table cell.
+func (m *MarkdownRenderer) RenderValidations(validations []types.Validation) []string {
+	descriptions := describeValidations(validations, func(s string) string {
+		return "`" + escapeCodeSpan(s) + "`"
+	})
+	for i, d := range descriptions {
+		descriptions[i] = escapeOutsideCodeSpans(d)
+	}
+	return descriptions
+}
+
+// escapeOutsideCodeSpans escapes the text outside of the code spans of a Markdown table cell, the code spans
+// being escaped with escapeCodeSpan.
+func escapeOutsideCodeSpans(text string) string {
+	parts := strings.Split(text, "`")
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = escapeTableCell(parts[i])
+	}
+	return strings.Join(parts, "`")
+}
+
+// escapeTableCell escapes text so that it can be used in a Markdown table cell.
+func escapeTableCell(out string) string {
 	// Escape the pipe character, which has special meaning for Markdown as a way to format tables
 	// so that including | in a comment does not result in wonky tables.
 	out = strings.ReplaceAll(out, "|", "\\|")
@@ -186,6 +299,34 @@ func (m *MarkdownRenderer) RenderFieldDoc(text string) string {
 	return strings.ReplaceAll(out, "<br /><br />", "<br />")
 }
 
+// RenderDefaultValue renders a default value for a table cell, as an inline JSON literal or, for values spanning
+// multiple lines, as a YAML block.
+func (m *MarkdownRenderer) RenderDefaultValue(value any) string {
+	return m.renderValue(value)
+}
+
+// RenderExample renders the example value of a field for a table cell, like RenderDefaultValue.
+func (m *MarkdownRenderer) RenderExample(value any) string {
+	return m.renderValue(value)
+}
+
+func (m *MarkdownRenderer) renderValue(value any) string {
+	text, block := formatValue(value)
+	if text == "" {
+		return ""
+	}
+	if block {
+		return escapeTableCell("<pre>" + html.EscapeString(text) + "</pre>")
+	}
+	return "`" + escapeCodeSpan(text) + "`"
+}
+
+// escapeCodeSpan escapes code so that it can be used in a code span of a Markdown table cell.
+// Only the pipe character needs escaping: other characters have no special meaning in code spans.
+func escapeCodeSpan(code string) string {
+	return strings.ReplaceAll(code, "|", "\\|")
+}
+
 func (m *MarkdownRenderer) RenderDefault(text string) string {
 	return strings.NewReplacer(
 		"{", "\\{",
@@ -17,7 +17,10 @@
 package renderer
 
 import (
+	"archive/tar"
+	"bytes"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -30,7 +33,12 @@ import (
 const mainTemplate = "gvList"
 
 type Renderer interface {
+	// Render writes the documentation to the output path given in the configuration.
 	Render(gvd []types.GroupVersionDetails) error
+	// RenderTo writes the documentation to w. In group mode, the output is a tar
+	// archive containing one file per group. Unlike Render, it does not write the
+	// inventory file.
+	RenderTo(w io.Writer, gvd []types.GroupVersionDetails) error
 }
 
 func New(conf *config.Config) (Renderer, error) {
@@ -39,13 +47,38 @@ func New(conf *config.Config) (Renderer, error) {
 		return NewAsciidoctorRenderer(conf)
 	case "markdown":
 		return NewMarkdownRenderer(conf)
+	case "jsonschema":
+		return NewJSONSchemaRenderer(conf)
 	default:
 		return nil, fmt.Errorf("unknown renderer: %s", conf.Renderer)
 	}
 }
 
-func loadTemplate(templatesFS fs.FS, funcs template.FuncMap) (*template.Template, error) {
-	return template.New("").Funcs(funcs).ParseFS(templatesFS, "*.tpl")
+const baseTemplatePrefix = "base/"
+
+// loadTemplate parses the default templates and, if given, layers the templates from overridesFS on top of them.
+// Templates that are not redefined by the overrides fall back to the defaults, which also remain available under
+// their original name prefixed with "base/" (e.g. {{ template "base/type" . }}).
+func loadTemplate(defaultsFS, overridesFS fs.FS, funcs template.FuncMap) (*template.Template, error) {
+	tmpl, err := template.New("").Funcs(funcs).ParseFS(defaultsFS, "*.tpl")
+	if err != nil {
+		return nil, err
+	}
+
+	if overridesFS == nil {
+		return tmpl, nil
+	}
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil || t.Name() == "" {
+			continue
+		}
+		if _, err := tmpl.AddParseTree(baseTemplatePrefix+t.Name(), t.Tree); err != nil {
+			return nil, err
+		}
+	}
+
+	return tmpl.ParseFS(overridesFS, "*.tpl")
 }
 
 type funcMap struct {
@@ -64,42 +97,118 @@ func combinedFuncMap(funcs ...funcMap) template.FuncMap {
 	return m
 }
 
-// renderTemplate applies a given template to a set of GroupVersionDetails and writes the output to files, it supports
-// two output modes as specified in the configuration: single mode or group mode.
+// outputFile is a rendered document along with the name it is written under.
+type outputFile struct {
+	name    string
+	content []byte
+	// gvds are the group versions documented in the file, if any.
+	gvds []types.GroupVersionDetails
+}
+
+// executeTemplate applies a given template to a set of GroupVersionDetails, it supports two output modes as specified
+// in the configuration: single mode or group mode.
 // In single mode, all data is rendered into one output file.
 // In group mode, separate files are created for each group.
-func renderTemplate(tmpl *template.Template, conf *config.Config, fileExtension string, gvds []types.GroupVersionDetails) error {
+func executeTemplate(tmpl *template.Template, conf *config.Config, fileExtension string, gvds []types.GroupVersionDetails) ([]outputFile, error) {
+	var files []outputFile
 	switch conf.OutputMode {
 	case config.OutputModeSingle:
-		fileName := fmt.Sprintf("%s.%s", "out", fileExtension)
-		file, err := createOutFile(conf.OutputPath, false, fileName)
-		defer file.Close()
-		if err != nil {
-			return err
-		}
-
-		if err := tmpl.ExecuteTemplate(file, mainTemplate, gvds); err != nil {
-			return err
+		buf := new(bytes.Buffer)
+		if err := tmpl.ExecuteTemplate(buf, mainTemplate, gvds); err != nil {
+			return nil, err
 		}
+		files = append(files, outputFile{name: fmt.Sprintf("%s.%s", "out", fileExtension), content: buf.Bytes(), gvds: gvds})
 
 	case config.OutputModeGroup:
+		// all the versions of a group are rendered in the file of the group, whose name must be unique
+		var groups []string
+		byGroup := make(map[string][]types.GroupVersionDetails)
 		for _, gvd := range gvds {
-			fileName := fmt.Sprintf("%s.%s", gvd.Group, fileExtension)
-			file, err := createOutFile(conf.OutputPath, true, fileName)
-			defer file.Close()
-			if err != nil {
-				return err
+			if _, ok := byGroup[gvd.Group]; !ok {
+				groups = append(groups, gvd.Group)
 			}
+			byGroup[gvd.Group] = append(byGroup[gvd.Group], gvd)
+		}
 
-			if err := tmpl.ExecuteTemplate(file, mainTemplate, []types.GroupVersionDetails{gvd}); err != nil {
-				return err
+		for _, group := range groups {
+			buf := new(bytes.Buffer)
+			gvdList := byGroup[group]
+			if err := tmpl.ExecuteTemplate(buf, mainTemplate, gvdList); err != nil {
+				return nil, err
 			}
+			files = append(files, outputFile{name: fmt.Sprintf("%s.%s", group, fileExtension), content: buf.Bytes(), gvds: gvdList})
+		}
+	}
+
+	return files, nil
+}
+
+// writeFiles writes the rendered files to the configured output path, or to stdout if the output path is "-".
+func writeFiles(conf *config.Config, files []outputFile) error {
+	archive := conf.OutputMode == config.OutputModeGroup
+	if conf.OutputPath == config.OutputPathStdout {
+		return writeOutput(os.Stdout, archive, files)
+	}
+
+	return writeOutFiles(conf.OutputPath, archive, files)
+}
+
+// writeOutFiles writes the rendered files to outputPath. If expectedDir is true, outputPath must be an existing
+// directory.
+func writeOutFiles(outputPath string, expectedDir bool, files []outputFile) error {
+	for _, f := range files {
+		if err := writeOutFile(outputPath, expectedDir, f); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+func writeOutFile(outputPath string, expectedDir bool, f outputFile) error {
+	file, err := createOutFile(outputPath, expectedDir, f.name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(f.content); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// writeOutput writes the rendered files to w. If archive is true, the files are bundled in a tar archive, otherwise
+// their content is written as is.
+func writeOutput(w io.Writer, archive bool, files []outputFile) error {
+	if !archive {
+		for _, f := range files {
+			if _, err := w.Write(f.content); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tw := tar.NewWriter(w)
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name: f.name,
+			Mode: 0o644,
+			Size: int64(len(f.content)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.content); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
 // createOutFile creates the file pointed to by outputPath if it does not exist, or if it exists and is a directory,
 // then creates a file in the directory using the given defaultFilename. If expectedDir is true, outputPath must be an
 // existing directory where defaultFileName is created.
@@ -0,0 +1,136 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRenderTo(t *testing.T) {
+	templatesDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "gv_list.tpl"),
+		[]byte(`{{ define "gvList" }}{{ range . }}{{ .GroupVersionString }};{{ end }}{{ end }}`), 0o644))
+	gvds := []types.GroupVersionDetails{
+		{GroupVersion: schema.GroupVersion{Group: "a.example.com", Version: "v1"}},
+		{GroupVersion: schema.GroupVersion{Group: "a.example.com", Version: "v2"}},
+		{GroupVersion: schema.GroupVersion{Group: "b.example.com", Version: "v1"}},
+	}
+	inventoryPath := filepath.Join(t.TempDir(), "inventory.json")
+	newRenderer := func(t *testing.T, outputMode string) *MarkdownRenderer {
+		t.Helper()
+		r, err := NewMarkdownRenderer(&config.Config{
+			Render: config.RenderConfig{Inventory: &config.InventoryConfig{Path: inventoryPath}},
+			Flags:  config.Flags{OutputMode: outputMode, TemplatesDir: templatesDir},
+		})
+		require.NoError(t, err)
+		return r
+	}
+
+	t.Run("single", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		require.NoError(t, newRenderer(t, config.OutputModeSingle).RenderTo(buf, gvds))
+		require.Equal(t, "a.example.com/v1;a.example.com/v2;b.example.com/v1;", buf.String())
+		require.NoFileExists(t, inventoryPath)
+	})
+
+	t.Run("group", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		require.NoError(t, newRenderer(t, config.OutputModeGroup).RenderTo(buf, gvds))
+		require.NoFileExists(t, inventoryPath)
+
+		var files []string
+		names := make(map[string]struct{})
+		tr := tar.NewReader(buf)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			require.NotContains(t, names, hdr.Name, "duplicate archive entry")
+			names[hdr.Name] = struct{}{}
+			content, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			files = append(files, hdr.Name+"="+string(content))
+		}
+
+		require.Equal(t, []string{
+			"a.example.com.md=a.example.com/v1;a.example.com/v2;",
+			"b.example.com.md=b.example.com/v1;",
+		}, files)
+	})
+}
+
+func TestRender(t *testing.T) {
+	templatesDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "gv_list.tpl"),
+		[]byte(`{{ define "gvList" }}{{ range . }}{{ .GroupVersionString }};{{ end }}{{ end }}`), 0o644))
+	outputPath := t.TempDir()
+	inventoryPath := filepath.Join(t.TempDir(), "inventory.json")
+
+	r, err := NewMarkdownRenderer(&config.Config{
+		Render: config.RenderConfig{Inventory: &config.InventoryConfig{Path: inventoryPath}},
+		Flags:  config.Flags{OutputMode: config.OutputModeSingle, OutputPath: outputPath, TemplatesDir: templatesDir},
+	})
+	require.NoError(t, err)
+	require.NoError(t, r.Render([]types.GroupVersionDetails{{GroupVersion: schema.GroupVersion{Group: "a.example.com", Version: "v1"}}}))
+
+	content, err := os.ReadFile(filepath.Join(outputPath, "out.md"))
+	require.NoError(t, err)
+	require.Equal(t, "a.example.com/v1;", string(content))
+	require.FileExists(t, inventoryPath)
+}
+
+func TestLoadTemplate(t *testing.T) {
+	defaults := fstest.MapFS{
+		"gv_list.tpl": {Data: []byte(`{{ define "gvList" }}[{{ template "type" . }}|{{ template "type_members" . }}]{{ end }}`)},
+		"type.tpl":    {Data: []byte(`{{ define "type" }}default type{{ end }}`)},
+		"members.tpl": {Data: []byte(`{{ define "type_members" }}default members{{ end }}`)},
+	}
+
+	t.Run("defaults only", func(t *testing.T) {
+		tmpl, err := loadTemplate(defaults, nil, nil)
+		require.NoError(t, err)
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, tmpl.ExecuteTemplate(buf, mainTemplate, nil))
+		require.Equal(t, "[default type|default members]", buf.String())
+	})
+
+	t.Run("partial override", func(t *testing.T) {
+		overrides := fstest.MapFS{
+			"type.tpl": {Data: []byte(`{{ define "type" }}custom {{ template "base/type" . }}{{ end }}`)},
+		}
+		tmpl, err := loadTemplate(defaults, overrides, nil)
+		require.NoError(t, err)
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, tmpl.ExecuteTemplate(buf, mainTemplate, nil))
+		require.Equal(t, "[custom default type|default members]", buf.String())
+	})
+}
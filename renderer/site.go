@@ -0,0 +1,296 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/goccy/go-yaml"
+	"golang.org/x/text/unicode/norm"
+)
+
+const defaultSiteTitle = "API Reference"
+
+var (
+	mkdocsInvalidSlugChars = regexp.MustCompile(`[^\w\s-]`)
+	mkdocsSlugSeparators   = regexp.MustCompile(`[-\s]+`)
+)
+
+// defaultFrontMatter is the front matter added to each file for a given site generator, unless overridden in the
+// configuration.
+var defaultFrontMatter = map[config.SiteGenerator]map[string]string{
+	config.SiteGeneratorHugo: {
+		"title":  "{{ .Title }}",
+		"weight": "{{ .Weight }}",
+	},
+	config.SiteGeneratorDocusaurus: {
+		"title":            "{{ .Title }}",
+		"sidebar_position": "{{ .Weight }}",
+	},
+	config.SiteGeneratorMkDocs: {
+		"title": "{{ .Title }}",
+	},
+}
+
+// frontMatterData is the data available to front matter templates.
+type frontMatterData struct {
+	// Title is the group name in group mode, or the site title in single mode.
+	Title    string
+	Group    string
+	Versions []string
+	Kinds    []string
+	// Weight is the 1-based position of the file.
+	Weight int
+}
+
+// siteIntegration adapts the Markdown output to a static site generator. A nil siteIntegration leaves the output
+// untouched.
+type siteIntegration struct {
+	generator   config.SiteGenerator
+	title       string
+	frontMatter map[string]*template.Template
+}
+
+func newSiteIntegration(conf *config.Config) (*siteIntegration, error) {
+	siteConf := conf.Render.Site
+	if siteConf == nil || siteConf.Generator == "" {
+		return nil, nil
+	}
+
+	site := &siteIntegration{
+		generator:   siteConf.Generator,
+		title:       siteConf.Title,
+		frontMatter: make(map[string]*template.Template),
+	}
+	if site.title == "" {
+		site.title = defaultSiteTitle
+	}
+
+	frontMatter := make(map[string]string)
+	for k, v := range defaultFrontMatter[siteConf.Generator] {
+		frontMatter[k] = v
+	}
+	for k, v := range siteConf.FrontMatter {
+		frontMatter[k] = v
+	}
+
+	for k, v := range frontMatter {
+		tmpl, err := template.New(k).Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse front matter template for %s: %w", k, err)
+		}
+		site.frontMatter[k] = tmpl
+	}
+
+	return site, nil
+}
+
+// integrate adds front matter to the rendered files and, in group mode, the navigation metadata of the generator.
+func (s *siteIntegration) integrate(files []outputFile, outputMode string) ([]outputFile, error) {
+	if s == nil {
+		return files, nil
+	}
+
+	// files with the same name are written to the same path, so they share a weight
+	weights := make(map[string]int)
+	for i := range files {
+		weight, ok := weights[files[i].name]
+		if !ok {
+			weight = len(weights) + 1
+			weights[files[i].name] = weight
+		}
+		data := frontMatterData{Title: s.title, Weight: weight}
+		for _, gvd := range files[i].gvds {
+			data.Group = gvd.Group
+			data.Versions = append(data.Versions, gvd.Version)
+			data.Kinds = append(data.Kinds, gvd.SortedKinds()...)
+		}
+		if outputMode == config.OutputModeGroup {
+			data.Title = data.Group
+		}
+
+		header, err := s.renderFrontMatter(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render front matter of %s: %w", files[i].name, err)
+		}
+		files[i].content = append(header, files[i].content...)
+	}
+
+	if outputMode != config.OutputModeGroup {
+		return files, nil
+	}
+
+	nav, err := s.navigation(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(files, nav), nil
+}
+
+func (s *siteIntegration) renderFrontMatter(data frontMatterData) ([]byte, error) {
+	keys := make([]string, 0, len(s.frontMatter))
+	for k := range s.frontMatter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make(yaml.MapSlice, 0, len(keys))
+	for _, k := range keys {
+		buf := new(bytes.Buffer)
+		if err := s.frontMatter[k].Execute(buf, data); err != nil {
+			return nil, err
+		}
+		values = append(values, yaml.MapItem{Key: k, Value: frontMatterValue(buf.String())})
+	}
+
+	return marshalFrontMatter(values)
+}
+
+// frontMatterValue keeps numbers and booleans typed, so that e.g. weights are not rendered as strings.
+func frontMatterValue(s string) any {
+	if i, err := strconv.Atoi(s); err == nil {
+		return i
+	}
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	return s
+}
+
+func marshalFrontMatter(values yaml.MapSlice) ([]byte, error) {
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("---\n")
+	buf.Write(out)
+	buf.WriteString("---\n\n")
+	return buf.Bytes(), nil
+}
+
+// navigation generates the navigation metadata of the generator for the given files.
+func (s *siteIntegration) navigation(files []outputFile) (outputFile, error) {
+	switch s.generator {
+	case config.SiteGeneratorDocusaurus:
+		content, err := json.MarshalIndent(map[string]any{"label": s.title}, "", "  ")
+		if err != nil {
+			return outputFile{}, err
+		}
+		return outputFile{name: "_category_.json", content: append(content, '\n')}, nil
+
+	case config.SiteGeneratorHugo:
+		content, err := marshalFrontMatter(yaml.MapSlice{{Key: "title", Value: s.title}})
+		if err != nil {
+			return outputFile{}, err
+		}
+		return outputFile{name: "_index.md", content: content}, nil
+
+	case config.SiteGeneratorMkDocs:
+		pages := make([]yaml.MapSlice, 0, len(files))
+		seen := make(map[string]struct{})
+		for _, f := range files {
+			if _, ok := seen[f.name]; ok {
+				continue
+			}
+			seen[f.name] = struct{}{}
+			title := strings.TrimSuffix(f.name, ".md")
+			if len(f.gvds) > 0 {
+				title = f.gvds[0].Group
+			}
+			pages = append(pages, yaml.MapSlice{{Key: title, Value: f.name}})
+		}
+		content, err := yaml.Marshal(yaml.MapSlice{{Key: "nav", Value: []yaml.MapSlice{{{Key: s.title, Value: pages}}}}})
+		if err != nil {
+			return outputFile{}, err
+		}
+		return outputFile{name: "mkdocs-nav.yml", content: content}, nil
+
+	default:
+		return outputFile{}, fmt.Errorf("unknown site generator: %s", s.generator)
+	}
+}
+
+// slug returns the anchor the generator derives from a heading with the given text.
+func (s *siteIntegration) slug(text string) string {
+	if s == nil {
+		return legacySlug(text)
+	}
+
+	switch s.generator {
+	case config.SiteGeneratorHugo, config.SiteGeneratorDocusaurus:
+		return githubSlug(text)
+	case config.SiteGeneratorMkDocs:
+		return mkdocsSlug(text)
+	default:
+		return legacySlug(text)
+	}
+}
+
+// legacySlug is the anchor format used when no site generator is configured.
+func legacySlug(text string) string {
+	return strings.ToLower(
+		strings.NewReplacer(
+			" ", "-",
+			".", "",
+			"/", "",
+			"(", "",
+			")", "",
+		).Replace(text),
+	)
+}
+
+// githubSlug follows the GitHub heading anchor rules, which are used by both Hugo (goldmark) and Docusaurus
+// (github-slugger): the text is lower-cased, punctuation is removed and spaces are replaced by hyphens.
+func githubSlug(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == ' ':
+			sb.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// mkdocsSlug follows the default slugify function of the MkDocs toc extension: the text is decomposed (NFKD) so that
+// accented letters keep their base letter, the remaining non-ASCII characters and punctuation are removed, and runs
+// of hyphens and whitespace are collapsed into a single hyphen.
+func mkdocsSlug(text string) string {
+	ascii := strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII {
+			return -1
+		}
+		return r
+	}, norm.NFKD.String(text))
+	slug := mkdocsInvalidSlugChars.ReplaceAllString(ascii, "")
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	return mkdocsSlugSeparators.ReplaceAllString(slug, "-")
+}
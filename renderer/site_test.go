@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"testing"
+	"text/template"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestSiteIntegration_slug(t *testing.T) {
+	testCases := []struct {
+		generator config.SiteGenerator
+		text      string
+		want      string
+	}{
+		{generator: "", text: "webapp.example.com/v1", want: "webappexamplecomv1"},
+		{generator: "", text: "Foo (Bar)", want: "foo-bar"},
+		{generator: config.SiteGeneratorDocusaurus, text: "webapp.example.com/v1", want: "webappexamplecomv1"},
+		{generator: config.SiteGeneratorDocusaurus, text: "Foo - Bar_Baz", want: "foo---bar_baz"},
+		{generator: config.SiteGeneratorHugo, text: "Foo (Bar)", want: "foo-bar"},
+		{generator: config.SiteGeneratorMkDocs, text: "Foo - Bar_Baz", want: "foo-bar_baz"},
+		{generator: config.SiteGeneratorMkDocs, text: "  Grüße / v1 ", want: "grue-v1"},
+		{generator: config.SiteGeneratorMkDocs, text: "Ｃafé ﬁle", want: "cafe-file"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.generator)+"/"+tc.text, func(t *testing.T) {
+			site, err := newSiteIntegration(&config.Config{Render: config.RenderConfig{Site: &config.SiteConfig{Generator: tc.generator}}})
+			require.NoError(t, err)
+			require.Equal(t, tc.want, site.slug(tc.text))
+		})
+	}
+}
+
+func TestSiteIntegration_integrate(t *testing.T) {
+	site, err := newSiteIntegration(&config.Config{Render: config.RenderConfig{Site: &config.SiteConfig{
+		Generator:   config.SiteGeneratorDocusaurus,
+		FrontMatter: map[string]string{"slug": "/api/{{ .Group }}"},
+	}}})
+	require.NoError(t, err)
+
+	gvd := types.GroupVersionDetails{GroupVersion: schema.GroupVersion{Group: "example.com", Version: "v1"}}
+	files, err := site.integrate([]outputFile{
+		{name: "example.com.md", content: []byte("# API Reference\n"), gvds: []types.GroupVersionDetails{gvd}},
+	}, config.OutputModeGroup)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	require.Equal(t, "example.com.md", files[0].name)
+	require.Equal(t, `---
+sidebar_position: 1
+slug: /api/example.com
+title: example.com
+---
+
+# API Reference
+`, string(files[0].content))
+
+	require.Equal(t, "_category_.json", files[1].name)
+	require.JSONEq(t, `{"label": "API Reference"}`, string(files[1].content))
+}
+
+func TestSiteIntegration_integrateGroupVersions(t *testing.T) {
+	site, err := newSiteIntegration(&config.Config{Render: config.RenderConfig{Site: &config.SiteConfig{
+		Generator:   config.SiteGeneratorMkDocs,
+		FrontMatter: map[string]string{"versions": "{{ range $i, $v := .Versions }}{{ if $i }},{{ end }}{{ $v }}{{ end }}"},
+	}}})
+	require.NoError(t, err)
+
+	tmpl := template.Must(template.New(mainTemplate).Parse(`{{ range . }}{{ .Version }};{{ end }}`))
+	files, err := executeTemplate(tmpl, &config.Config{Flags: config.Flags{OutputMode: config.OutputModeGroup}}, "md",
+		[]types.GroupVersionDetails{
+			{GroupVersion: schema.GroupVersion{Group: "a.example.com", Version: "v1"}},
+			{GroupVersion: schema.GroupVersion{Group: "a.example.com", Version: "v2"}},
+			{GroupVersion: schema.GroupVersion{Group: "b.example.com", Version: "v1"}},
+		})
+	require.NoError(t, err)
+
+	files, err = site.integrate(files, config.OutputModeGroup)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	require.Equal(t, "a.example.com.md", files[0].name)
+	require.Equal(t, "---\ntitle: a.example.com\nversions: v1,v2\n---\n\nv1;v2;", string(files[0].content))
+	require.Equal(t, "b.example.com.md", files[1].name)
+
+	require.Equal(t, "mkdocs-nav.yml", files[2].name)
+	require.Equal(t, `nav:
+- API Reference:
+  - a.example.com: a.example.com.md
+  - b.example.com: b.example.com.md
+`, string(files[2].content))
+}
+
+func TestSiteIntegration_navigationDuplicateFiles(t *testing.T) {
+	site, err := newSiteIntegration(&config.Config{Render: config.RenderConfig{Site: &config.SiteConfig{
+		Generator: config.SiteGeneratorMkDocs,
+	}}})
+	require.NoError(t, err)
+
+	nav, err := site.navigation([]outputFile{{name: "a.example.com.md"}, {name: "a.example.com.md"}})
+	require.NoError(t, err)
+	require.Equal(t, "nav:\n- API Reference:\n  - a.example.com: a.example.com.md\n", string(nav.content))
+}
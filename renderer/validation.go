@@ -0,0 +1,254 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/elastic/crd-ref-docs/types"
+)
+
+// describeValidations describes the validation rules in prose, merging related rules, e.g. "1 ≤ value ≤ 5" or
+// "at most 10 items, unique". The rules applying to the items of a list are described after the others, prefixed
+// with "items:". Direct rules are described before the rules inherited from each type, suffixed with the name of the
+// type, and conflicting rules are described last. code formats literal values, such as patterns, for the output
+// format.
+func describeValidations(validations []types.Validation, code func(string) string) []string {
+	var sources []string
+	bySource := make(map[string][]types.Validation)
+	var conflicts []types.Validation
+	for _, v := range validations {
+		if v.Conflict {
+			conflicts = append(conflicts, v)
+			continue
+		}
+		if _, ok := bySource[v.From]; !ok {
+			sources = append(sources, v.From)
+		}
+		bySource[v.From] = append(bySource[v.From], v)
+	}
+
+	var descriptions []string
+	for _, source := range sources {
+		for _, d := range describeSource(bySource[source], code) {
+			if source != "" {
+				d += " (from " + code(source) + ")"
+			}
+			descriptions = append(descriptions, d)
+		}
+	}
+	return append(descriptions, describeConflicts(conflicts, code)...)
+}
+
+// describeSource describes the rules declared by the same type.
+func describeSource(validations []types.Validation, code func(string) string) []string {
+	var own, items []types.Validation
+	for _, v := range validations {
+		if v.Items {
+			items = append(items, v)
+		} else {
+			own = append(own, v)
+		}
+	}
+
+	descriptions := describeRules(own, code)
+	for _, d := range describeRules(items, code) {
+		descriptions = append(descriptions, "items: "+d)
+	}
+	return descriptions
+}
+
+// describeConflicts describes the rules of the same kind with different values, e.g. "conflicting Maximum: 4, 5".
+func describeConflicts(validations []types.Validation, code func(string) string) []string {
+	type key struct {
+		kind  types.ValidationKind
+		items bool
+	}
+
+	var keys []key
+	values := make(map[key][]string)
+	for _, v := range validations {
+		k := key{kind: v.Kind, items: v.Items}
+		if _, ok := values[k]; !ok {
+			keys = append(keys, k)
+		}
+		value := code(fmt.Sprint(v.Value))
+		if v.Kind == types.ValidationEnum {
+			value = "{" + describeEnum(v.Value, code) + "}"
+		}
+		values[k] = append(values[k], value)
+	}
+
+	descriptions := make([]string, 0, len(keys))
+	for _, k := range keys {
+		d := fmt.Sprintf("conflicting %s: %s", k.kind, strings.Join(values[k], ", "))
+		if k.items {
+			d = "items: " + d
+		}
+		descriptions = append(descriptions, d)
+	}
+	return descriptions
+}
+
+// describeRules describes the rules applying to the same value.
+func describeRules(validations []types.Validation, code func(string) string) []string {
+	rules := make(map[types.ValidationKind]any)
+	var others []types.Validation
+	for _, v := range validations {
+		switch v.Kind {
+		case types.ValidationMinimum, types.ValidationMaximum, types.ValidationExclusiveMinimum,
+			types.ValidationExclusiveMaximum, types.ValidationMultipleOf, types.ValidationMinLength,
+			types.ValidationMaxLength, types.ValidationPattern, types.ValidationFormat, types.ValidationEnum,
+			types.ValidationType, types.ValidationMinItems, types.ValidationMaxItems, types.ValidationUniqueItems,
+			types.ValidationMinProperties, types.ValidationMaxProperties, types.ValidationRequired,
+			types.ValidationOptional:
+			rules[v.Kind] = v.Value
+		case types.ValidationXValidation:
+			// CEL rules are too long to be described in a table cell
+		case types.ValidationEmbeddedResource, types.ValidationXEmbeddedResource,
+			types.ValidationXPreserveUnknownFields, types.ValidationSchemaless:
+			// described by the notes of the schema flags
+		default:
+			others = append(others, v)
+		}
+	}
+
+	var descriptions []string
+	add := func(d string) {
+		if d != "" {
+			descriptions = append(descriptions, d)
+		}
+	}
+
+	if _, ok := rules[types.ValidationRequired]; ok {
+		add("required")
+	} else if _, ok := rules[types.ValidationOptional]; ok {
+		add("optional")
+	}
+	if t, ok := rules[types.ValidationType]; ok {
+		add(fmt.Sprintf("type: %v", t))
+	}
+	add(describeRange(rules))
+	if m, ok := rules[types.ValidationMultipleOf]; ok {
+		add(fmt.Sprintf("multiple of %v", m))
+	}
+	add(describeCount(rules[types.ValidationMinLength], rules[types.ValidationMaxLength], "character", "characters"))
+	items := describeCount(rules[types.ValidationMinItems], rules[types.ValidationMaxItems], "item", "items")
+	if isTrue(rules[types.ValidationUniqueItems]) {
+		if items == "" {
+			items = "unique items"
+		} else {
+			items += ", unique"
+		}
+	}
+	add(items)
+	add(describeCount(rules[types.ValidationMinProperties], rules[types.ValidationMaxProperties], "property", "properties"))
+	if p, ok := rules[types.ValidationPattern]; ok {
+		add("matches " + code(fmt.Sprint(p)))
+	}
+	if f, ok := rules[types.ValidationFormat]; ok {
+		add("format: " + code(fmt.Sprint(f)))
+	}
+	if e, ok := rules[types.ValidationEnum]; ok {
+		add("one of: " + describeEnum(e, code))
+	}
+
+	for _, v := range others {
+		// markers without arguments, e.g. EmbeddedResource
+		if fmt.Sprint(v.Value) == "{}" {
+			add(string(v.Kind))
+		} else {
+			add(fmt.Sprintf("%s: %v", v.Kind, v.Value))
+		}
+	}
+
+	return descriptions
+}
+
+// describeRange describes the bounds of a number, e.g. "1 ≤ value < 5".
+func describeRange(rules map[types.ValidationKind]any) string {
+	minimum, hasMin := rules[types.ValidationMinimum]
+	maximum, hasMax := rules[types.ValidationMaximum]
+	exclusiveMin := isTrue(rules[types.ValidationExclusiveMinimum])
+	exclusiveMax := isTrue(rules[types.ValidationExclusiveMaximum])
+
+	switch {
+	case hasMin && hasMax:
+		return fmt.Sprintf("%v %s value %s %v", minimum, lessThan(exclusiveMin), lessThan(exclusiveMax), maximum)
+	case hasMin:
+		return fmt.Sprintf("value %s %v", greaterThan(exclusiveMin), minimum)
+	case hasMax:
+		return fmt.Sprintf("value %s %v", lessThan(exclusiveMax), maximum)
+	default:
+		return ""
+	}
+}
+
+func lessThan(exclusive bool) string {
+	if exclusive {
+		return "<"
+	}
+	return "≤"
+}
+
+func greaterThan(exclusive bool) string {
+	if exclusive {
+		return ">"
+	}
+	return "≥"
+}
+
+// describeCount describes bounds on a number of things, e.g. "at most 10 items".
+func describeCount(minimum, maximum any, singular, plural string) string {
+	noun := func(n any) string {
+		if fmt.Sprint(n) == "1" {
+			return singular
+		}
+		return plural
+	}
+
+	switch {
+	case minimum != nil && maximum != nil:
+		return fmt.Sprintf("%v to %v %s", minimum, maximum, plural)
+	case minimum != nil:
+		return fmt.Sprintf("at least %v %s", minimum, noun(minimum))
+	case maximum != nil:
+		return fmt.Sprintf("at most %v %s", maximum, noun(maximum))
+	default:
+		return ""
+	}
+}
+
+// describeEnum lists the allowed values of an enum.
+func describeEnum(enum any, code func(string) string) string {
+	values := reflect.ValueOf(enum)
+	if values.Kind() != reflect.Slice {
+		return code(fmt.Sprint(enum))
+	}
+
+	formatted := make([]string, values.Len())
+	for i := range formatted {
+		formatted[i] = code(fmt.Sprint(values.Index(i).Interface()))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func isTrue(v any) bool {
+	return fmt.Sprint(v) == "true"
+}
@@ -0,0 +1,149 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"testing"
+
+	"github.com/elastic/crd-ref-docs/config"
+	"github.com/elastic/crd-ref-docs/types"
+	"github.com/stretchr/testify/require"
+	crdmarkers "sigs.k8s.io/controller-tools/pkg/crd/markers"
+)
+
+func TestDescribeValidations(t *testing.T) {
+	code := func(s string) string { return "`" + s + "`" }
+
+	tests := []struct {
+		name        string
+		validations []types.Validation
+		want        []string
+	}{
+		{
+			name: "range",
+			validations: []types.Validation{
+				{Kind: types.ValidationMaximum, Value: crdmarkers.Maximum(5)},
+				{Kind: types.ValidationMinimum, Value: crdmarkers.Minimum(1)},
+			},
+			want: []string{"1 ≤ value ≤ 5"},
+		},
+		{
+			name: "exclusive bounds",
+			validations: []types.Validation{
+				{Kind: types.ValidationExclusiveMaximum, Value: crdmarkers.ExclusiveMaximum(true)},
+				{Kind: types.ValidationExclusiveMinimum, Value: crdmarkers.ExclusiveMinimum(true)},
+				{Kind: types.ValidationMaximum, Value: crdmarkers.Maximum(1.5)},
+				{Kind: types.ValidationMinimum, Value: crdmarkers.Minimum(0)},
+			},
+			want: []string{"0 < value < 1.5"},
+		},
+		{
+			name:        "minimum only",
+			validations: []types.Validation{{Kind: types.ValidationMinimum, Value: crdmarkers.Minimum(1)}},
+			want:        []string{"value ≥ 1"},
+		},
+		{
+			name: "items",
+			validations: []types.Validation{
+				{Kind: types.ValidationMaxItems, Value: crdmarkers.MaxItems(10)},
+				{Kind: types.ValidationUniqueItems, Value: crdmarkers.UniqueItems(true)},
+			},
+			want: []string{"at most 10 items, unique"},
+		},
+		{
+			name: "length",
+			validations: []types.Validation{
+				{Kind: types.ValidationMaxLength, Value: crdmarkers.MaxLength(63)},
+				{Kind: types.ValidationMinLength, Value: crdmarkers.MinLength(1)},
+				{Kind: types.ValidationRequired, Value: struct{}{}},
+			},
+			want: []string{"required", "1 to 63 characters"},
+		},
+		{
+			name: "literals",
+			validations: []types.Validation{
+				{Kind: types.ValidationEnum, Value: crdmarkers.Enum{"OK", "Error"}},
+				{Kind: types.ValidationFormat, Value: crdmarkers.Format("date-time")},
+				{Kind: types.ValidationPattern, Value: crdmarkers.Pattern("^[a-z]+$")},
+			},
+			want: []string{"matches `^[a-z]+$`", "format: `date-time`", "one of: `OK`, `Error`"},
+		},
+		{
+			name: "items rules",
+			validations: []types.Validation{
+				{Kind: types.ValidationMinLength, Value: crdmarkers.MinLength(1), Items: true},
+				{Kind: types.ValidationMinItems, Value: crdmarkers.MinItems(1)},
+			},
+			want: []string{"at least 1 item", "items: at least 1 character"},
+		},
+		{
+			name: "unsupported and hidden rules",
+			validations: []types.Validation{
+				{Kind: types.ValidationXValidation, Value: crdmarkers.XValidation{Rule: "self.page < 200"}},
+				{Kind: types.ValidationEmbeddedResource, Value: crdmarkers.XEmbeddedResource{}},
+				{Kind: "Custom", Value: struct{}{}},
+				{Kind: "Other", Value: 3},
+			},
+			want: []string{"Custom", "Other: 3"},
+		},
+		{
+			name: "inherited",
+			validations: []types.Validation{
+				{Kind: types.ValidationMinLength, Value: crdmarkers.MinLength(1)},
+				{Kind: types.ValidationMaxLength, Value: crdmarkers.MaxLength(10), From: "Name"},
+				{Kind: types.ValidationMaxItems, Value: crdmarkers.MaxItems(3), From: "Names"},
+			},
+			want: []string{"at least 1 character", "at most 10 characters (from `Name`)", "at most 3 items (from `Names`)"},
+		},
+		{
+			name: "conflicts",
+			validations: []types.Validation{
+				{Kind: types.ValidationMinimum, Value: crdmarkers.Minimum(1), From: "Rating"},
+				{Kind: types.ValidationMaximum, Value: crdmarkers.Maximum(4), From: "Rating", Conflict: true},
+				{Kind: types.ValidationMaximum, Value: crdmarkers.Maximum(5), From: "Rating", Conflict: true},
+				{Kind: types.ValidationEnum, Value: crdmarkers.Enum{"a"}, Items: true, Conflict: true},
+				{Kind: types.ValidationEnum, Value: crdmarkers.Enum{"a", "b"}, Items: true, Conflict: true},
+			},
+			want: []string{
+				"value ≥ 1 (from `Rating`)",
+				"conflicting Maximum: `4`, `5`",
+				"items: conflicting Enum: {`a`}, {`a`, `b`}",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, describeValidations(tt.validations, code))
+		})
+	}
+}
+
+func TestRenderValidations(t *testing.T) {
+	validations := []types.Validation{
+		{Kind: types.ValidationPattern, Value: crdmarkers.Pattern(`^a|b{2}*$`)},
+		{Kind: types.ValidationEnum, Value: crdmarkers.Enum{"OK"}},
+	}
+
+	md, err := NewMarkdownRenderer(&config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"matches `^a\\|b{2}*$`", "one of: `OK`"}, md.RenderValidations(validations))
+
+	adr, err := NewAsciidoctorRenderer(&config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"matches `++^a\\|b{2}*$++`", "one of: `OK`"}, adr.RenderValidations(validations))
+}
@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/elastic/crd-ref-docs/types"
+	"github.com/goccy/go-yaml"
+)
+
+// formatValue formats a default or example value as a JSON literal, e.g. {"page":1} or "b", or as YAML for objects
+// and lists spanning multiple lines, in which case block is set.
+func formatValue(value any) (text string, block bool) {
+	if value == nil {
+		return "", false
+	}
+	if !isMultiLine(value) {
+		return types.DefaultString(value), false
+	}
+
+	b, err := yaml.Marshal(value)
+	if err != nil {
+		return types.DefaultString(value), false
+	}
+	return strings.TrimSuffix(string(b), "\n"), true
+}
+
+// isMultiLine reports whether the value is an object or a list with several entries, or with nested objects or lists.
+func isMultiLine(value any) bool {
+	var entries []any
+	if ordered, ok := value.(yaml.MapSlice); ok {
+		for _, item := range ordered {
+			entries = append(entries, item.Value)
+		}
+	} else {
+		v := reflect.ValueOf(value)
+		switch v.Kind() {
+		case reflect.Map:
+			iter := v.MapRange()
+			for iter.Next() {
+				entries = append(entries, iter.Value().Interface())
+			}
+		case reflect.Slice:
+			for i := range v.Len() {
+				entries = append(entries, v.Index(i).Interface())
+			}
+		default:
+			return false
+		}
+	}
+
+	if len(entries) > 1 {
+		return true
+	}
+	for _, entry := range entries {
+		switch reflect.Indirect(reflect.ValueOf(entry)).Kind() {
+		case reflect.Map, reflect.Slice:
+			return true
+		}
+	}
+	return false
+}
+
+// IsBlockValue reports whether the default or example value is rendered as a block, as it spans multiple lines.
+func (f *Functions) IsBlockValue(value any) bool {
+	_, block := formatValue(value)
+	return block
+}
+
+// ExampleYAML formats an example value as YAML, for the examples of types, which are always rendered as blocks.
+func (f *Functions) ExampleYAML(value any) string {
+	if value == nil {
+		return ""
+	}
+	b, err := yaml.Marshal(value)
+	if err != nil {
+		return types.DefaultString(value)
+	}
+	return strings.TrimSuffix(string(b), "\n")
+}
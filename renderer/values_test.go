@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package renderer
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		text  string
+		block bool
+	}{
+		{name: "none"},
+		{name: "string", value: "b", text: `"b"`},
+		{name: "number", value: 1, text: "1"},
+		{name: "single entry", value: map[string]any{"page": 1}, text: `{"page":1}`},
+		{name: "empty list", value: []string{}, text: "[]"},
+		{name: "list", value: []string{"guest", "visitor"}, text: "- guest\n- visitor", block: true},
+		{name: "nested", value: map[string]any{"page": map[string]any{"size": 10}}, text: "page:\n  size: 10", block: true},
+		{name: "ordered", value: yaml.MapSlice{{Key: "size", Value: 10}, {Key: "page", Value: 1}}, text: "size: 10\npage: 1", block: true},
+		{name: "ordered single entry", value: yaml.MapSlice{{Key: "page", Value: 1}}, text: `{"page":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, block := formatValue(tt.value)
+			require.Equal(t, tt.text, text)
+			require.Equal(t, tt.block, block)
+		})
+	}
+}
+
+func TestRenderDefaultValue(t *testing.T) {
+	m := &MarkdownRenderer{}
+	require.Equal(t, "", m.RenderDefaultValue(nil))
+	require.Equal(t, "`{\"page\":1}`", m.RenderDefaultValue(map[string]any{"page": 1}))
+	require.Equal(t, "`\"a\\|b\"`", m.RenderDefaultValue("a|b"))
+	require.Equal(t, "<pre>- a&lt;b<br />- c</pre>", m.RenderDefaultValue([]string{"a<b", "c"}))
+
+	adr := &AsciidoctorRenderer{}
+	require.Equal(t, "`1`", adr.RenderDefaultValue(1))
+	require.Equal(t, "`++\"b\"++`", adr.RenderDefaultValue("b"))
+	require.Equal(t, "\n[source,yaml]\n----\n- a\n- c\n----\n", adr.RenderDefaultValue([]string{"a", "c"}))
+}
+
+func TestExampleYAML(t *testing.T) {
+	f := &Functions{}
+	require.Equal(t, "", f.ExampleYAML(nil))
+	require.Equal(t, "3", f.ExampleYAML(3))
+	require.Equal(t, "name: alice\ntags:\n- guest", f.ExampleYAML(map[string]any{"tags": []any{"guest"}, "name": "alice"}))
+	require.True(t, f.IsBlockValue([]string{"a", "b"}))
+	require.False(t, f.IsBlockValue("a"))
+}
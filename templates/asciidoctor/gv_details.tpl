@@ -1,19 +1,47 @@
 {{- define "gvDetails" -}}
 {{- $gv := . -}}
 [id="{{ asciidocGroupVersionID $gv | asciidocRenderAnchorID }}"]
-=== {{ $gv.GroupVersionString }}
+=== {{ $gv.DisplayName }}
 
-{{ $gv.Doc }}
-
-{{- if $gv.Kinds  }}
+{{ asciidocRenderDoc $gv.Doc }}
+{{ if $gv.Kinds  }}
 .Resource Types
 {{- range $gv.SortedKinds }}
 - {{ $gv.TypeForKind . | asciidocRenderTypeLink }}
 {{- end }}
 {{ end }}
 
-{{ range $gv.SortedTypes }}
+{{ range $gv.TypeGroups asciidocTypeOrder }}
+{{- if .Name }}
+
+[discrete]
+==== {{ .Name }}
+{{ end }}
+{{- range .Types }}
+{{- if not .ObservedState }}
 {{ template "type" . }}
 {{ end }}
+{{- end }}
+{{- end }}
+
+{{- range asciidocObservedStateSections $gv asciidocTypeOrder }}
+{{- $mode := asciidocObservedStateMode .Kind }}
+{{- if ne $mode "omit" }}
+
+[discrete]
+==== {{ .Kind }} Observed State
+{{ if eq $mode "collapse" }}
+.Types describing the observed state of {{ .Kind }}
+[%collapsible]
+======
+{{ end }}
+{{- range .Types }}
+{{ template "type" . }}
+{{ end }}
+{{- if eq $mode "collapse" }}
+======
+{{ end }}
+{{- end }}
+{{- end }}
 
 {{- end -}}
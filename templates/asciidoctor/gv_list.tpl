@@ -8,12 +8,32 @@
 == API Reference
 
 .Packages
-{{- range $groupVersions }}
+{{- range asciidocPackageGroups $groupVersions }}
+{{- if .Name }}
+- *{{ .Name }}*
+{{- range .GroupVersions }}
+** {{ asciidocRenderGVLink . }}
+{{- end }}
+{{- else }}
+{{- range .GroupVersions }}
 - {{ asciidocRenderGVLink . }}
 {{- end }}
+{{- end }}
+{{- end }}
 
-{{ range $groupVersions }}
+{{ range asciidocPackageGroups $groupVersions }}
+{{- range .GroupVersions }}
 {{ template "gvDetails" . }}
 {{ end }}
+{{- end }}
+
+{{- with asciidocKubeTypes $groupVersions }}
+[id="{anchor_prefix}-referenced-kubernetes-types"]
+=== Referenced Kubernetes types
+
+{{ range . }}
+{{ template "type" . }}
+{{ end }}
+{{- end }}
 
 {{- end -}}
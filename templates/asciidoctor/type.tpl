@@ -3,15 +3,33 @@
 {{- if asciidocShouldRenderType $type -}}
 
 [id="{{ asciidocTypeID $type | asciidocRenderAnchorID }}"]
-==== {{ $type.Name  }}
+{{ if asciidocIsCollapsed $type }}[discrete]
+{{ end }}==== {{ $type.DisplayName }}
 
-{{ if $type.IsAlias }}_Underlying type:_ _{{ asciidocRenderTypeLink $type.UnderlyingType  }}_{{ end }}
+{{ with $type.Badges }}{{ range $i, $badge := . }}{{ if $i }} {{ end }}[.badge]#{{ $badge }}#{{ end }}
 
-{{ $type.Doc }}
+{{ end }}{{ with $type.Deprecation }}WARNING: {{ .String }}
 
-{{ if $type.Validation -}}
+{{ end }}{{ if $type.IsAlias }}_Underlying type:_ _{{ asciidocRenderTypeLink $type.UnderlyingType  }}_{{ end }}
+
+{{ asciidocRenderDoc $type.Doc }}{{ range $type.SchemaFlags.Notes }}
+
+_{{ . }}_{{ end }}{{ with $type.Example }}
+
+.Example:
+[source,yaml]
+----
+{{ asciidocExampleYAML . }}
+----{{ end }}
+{{ if and $type.GVK asciidocShowTypeDiagrams }}
+[graphviz]
+....
+{{ asciidocRenderDOTDiagram $type }}
+....
+{{ end }}
+{{ with asciidocRenderValidations $type.Validations -}}
 .Validation:
-{{- range $type.Validation }}
+{{- range . }}
 - {{ . }}
 {{- end }}
 {{- end }}
@@ -34,10 +52,17 @@
 | *`kind`* __string__ | `{{ $type.GVK.Kind }}` | |
 {{ end -}}
 
-{{ range $type.Members -}}
-| *`{{ .Name }}`*{{ if .Aliases }} _(or {{ range $i, $a := .Aliases }}{{ if $i }}, {{ end }}`{{ $a }}`{{ end }})_{{ end }} __{{ asciidocRenderType .Type }}__ | {{ template "type_members" . }} | {{ .Default }} | {{ range .Validation -}} {{ asciidocRenderValidation . }} +
+{{ range $type.FieldGroups asciidocFieldOrder -}}
+{{ if .Name -}}
+4+| *{{ .Name }}*
+{{ end -}}
+{{ range .Fields -}}
+{{ if asciidocShouldRenderField $type . -}}
+| {{ if .Deprecated }}[.line-through]#{{ end }}*`{{ .Name }}`*{{ if .Deprecated }}#{{ end }}{{ if .Aliases }} _(or {{ range $i, $a := .Aliases }}{{ if $i }}, {{ end }}`{{ $a }}`{{ end }})_{{ end }} __{{ asciidocRenderType .Type }}__ | {{ template "type_members" . }} | {{ asciidocRenderDefaultValue .DefaultValue }} | {{ range asciidocRenderValidations .Validations -}} {{ . }} +
 {{ end }}
 {{ end -}}
+{{ end -}}
+{{ end -}}
 |===
 {{ end -}}
 
@@ -3,6 +3,16 @@
 {{- if eq $field.Name "metadata" -}}
 Refer to Kubernetes API documentation for fields of `metadata`.
 {{ else -}}
-{{ asciidocRenderFieldDoc $field.Doc }}
+{{ range $field.Badges }}[.badge]#{{ . }}# {{ end }}{{ if $field.Title }}*{{ $field.Title }}* +
+{{ end }}{{ asciidocRenderFieldDoc $field.Doc }}
+{{- range asciidocFieldNotes $field }}
+
+_{{ . }}_
+{{- end }}
+{{- with $field.Example }}
+
+_Example:_{{ if asciidocIsBlockValue . }}
+{{ else }} {{ end }}{{ asciidocRenderExample . }}
+{{- end }}
 {{- end -}}
 {{- end -}}
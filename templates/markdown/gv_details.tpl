@@ -1,19 +1,44 @@
 {{- define "gvDetails" -}}
 {{- $gv := . -}}
 
-## {{ $gv.GroupVersionString }}
+## {{ $gv.DisplayName }}
 
-{{ markdownRewriteLinks $gv.Doc }}
-
-{{- if $gv.Kinds  }}
+{{ markdownRenderDoc $gv.Doc }}
+{{ if $gv.Kinds  }}
 ### Resource Types
 {{- range $gv.SortedKinds }}
 - {{ $gv.TypeForKind . | markdownRenderTypeLink }}
 {{- end }}
 {{ end }}
 
-{{ range $gv.SortedTypes }}
+{{ range $gv.TypeGroups markdownTypeOrder }}
+{{- if .Name }}
+
+### {{ .Name }}
+{{ end }}
+{{- range .Types }}
+{{- if not .ObservedState }}
 {{ template "type" . }}
 {{ end }}
+{{- end }}
+{{- end }}
+
+{{- range markdownObservedStateSections $gv markdownTypeOrder }}
+{{- $mode := markdownObservedStateMode .Kind }}
+{{- if ne $mode "omit" }}
+
+### {{ .Kind }} Observed State
+{{ if eq $mode "collapse" }}
+<details>
+<summary>Types describing the observed state of {{ .Kind }}</summary>
+{{ end }}
+{{- range .Types }}
+{{ template "type" . }}
+{{ end }}
+{{- if eq $mode "collapse" }}
+</details>
+{{ end }}
+{{- end }}
+{{- end }}
 
 {{- end -}}
@@ -4,12 +4,31 @@
 # API Reference
 
 ## Packages
-{{- range $groupVersions }}
+{{- range markdownPackageGroups $groupVersions }}
+{{- if .Name }}
+- **{{ .Name }}**
+{{- range .GroupVersions }}
+  - {{ markdownRenderGVLink . }}
+{{- end }}
+{{- else }}
+{{- range .GroupVersions }}
 - {{ markdownRenderGVLink . }}
 {{- end }}
+{{- end }}
+{{- end }}
 
-{{ range $groupVersions }}
+{{ range markdownPackageGroups $groupVersions }}
+{{- range .GroupVersions }}
 {{ template "gvDetails" . }}
 {{ end }}
+{{- end }}
+
+{{- with markdownKubeTypes $groupVersions }}
+## Referenced Kubernetes types
+
+{{ range . }}
+{{ template "type" . }}
+{{ end }}
+{{- end }}
 
 {{- end -}}
@@ -2,15 +2,31 @@
 {{- $type := . -}}
 {{- if markdownShouldRenderType $type -}}
 
-#### {{ $type.Name }}
+#### {{ $type.DisplayName }}
 
-{{ if $type.IsAlias }}_Underlying type:_ _{{ markdownRenderTypeLink $type.UnderlyingType  }}_{{ end }}
+{{ with $type.Badges }}{{ range $i, $badge := . }}{{ if $i }} {{ end }}<kbd>{{ $badge }}</kbd>{{ end }}
 
-{{ markdownRewriteLinks $type.Doc }}
+{{ end }}{{ with $type.Deprecation }}> [!WARNING]
+> {{ .String }}
 
-{{ if $type.Validation -}}
+{{ end }}{{ if $type.IsAlias }}_Underlying type:_ _{{ markdownRenderTypeLink $type.UnderlyingType  }}_{{ end }}
+
+{{ markdownRenderDoc $type.Doc }}{{ range $type.SchemaFlags.Notes }}
+
+_{{ . }}_{{ end }}{{ with $type.Example }}
+
+_Example:_
+```yaml
+{{ markdownExampleYAML . }}
+```{{ end }}
+{{ if and $type.GVK markdownShowTypeDiagrams }}
+```mermaid
+{{ markdownRenderMermaidDiagram $type }}
+```
+{{ end }}
+{{ with markdownRenderValidations $type.Validations -}}
 _Validation:_
-{{- range $type.Validation }}
+{{- range . }}
 - {{ . }}
 {{- end }}
 {{- end }}
@@ -30,8 +46,15 @@ _Appears in:_
 | `kind` _string_ | `{{ $type.GVK.Kind }}` | | |
 {{ end -}}
 
-{{ range $type.Members -}}
-| `{{ .Name }}`{{ if .Aliases }}<br/>_(or {{ range $i, $a := .Aliases }}{{ if $i }}, {{ end }}`{{ $a }}`{{ end }})_{{ end }} _{{ markdownRenderType .Type }}_ | {{ template "type_members" . }} | {{ markdownRenderDefault .Default }} | {{ range .Validation -}} {{ markdownRenderFieldDoc . }} <br />{{ end }} |
+{{ range $type.FieldGroups markdownFieldOrder -}}
+{{ if .Name -}}
+| **{{ .Name }}** | | | |
+{{ end -}}
+{{ range .Fields -}}
+{{ if markdownShouldRenderField $type . -}}
+| {{ if .Deprecated }}~~{{ end }}`{{ .Name }}`{{ if .Deprecated }}~~{{ end }}{{ if .Aliases }}<br/>_(or {{ range $i, $a := .Aliases }}{{ if $i }}, {{ end }}`{{ $a }}`{{ end }})_{{ end }} _{{ markdownRenderType .Type }}_ | {{ template "type_members" . }} | {{ markdownRenderDefaultValue .DefaultValue }} | {{ range markdownRenderValidations .Validations -}} {{ . }} <br />{{ end }} |
+{{ end -}}
+{{ end -}}
 {{ end -}}
 
 {{ end -}}
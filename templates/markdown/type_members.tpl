@@ -3,6 +3,7 @@
 {{- if eq $field.Name "metadata" -}}
 Refer to Kubernetes API documentation for fields of `metadata`.
 {{- else -}}
-{{ markdownRenderFieldDoc $field.Doc }}
+{{ range $field.Badges }}<kbd>{{ . }}</kbd> {{ end }}{{ if $field.Title }}**{{ $field.Title }}** <br />{{ end }}{{ markdownRenderFieldDoc $field.Doc }}{{ range $i, $note := markdownFieldNotes $field }}{{ if or $i $field.Doc }} <br />{{ end }}_{{ $note }}_{{ end }}
+{{- with markdownRenderExample $field.Example }}{{ if or $field.Doc (markdownFieldNotes $field) }} <br />{{ end }}_Example:_ {{ . }}{{ end }}
 {{- end -}}
 {{- end -}}
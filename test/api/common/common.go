@@ -1,3 +1,4 @@
+// Package common holds the types shared by the webapp API versions.
 package common
 
 type CommonString string
@@ -16,6 +16,8 @@
 // under the License.
 
 // Package v1 contains API Schema definitions for the webapp v1 API group. See https://example.com/old-page for more.
+//
+// Copyright of the guestbook entries remains with their authors.
 // +kubebuilder:object:generate=true
 // +groupName=webapp.test.k8s.elastic.co
 package v1
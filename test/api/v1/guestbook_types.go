@@ -22,6 +22,7 @@ import (
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -55,6 +56,12 @@ type EmbeddedX struct {
 	X string `json:"x,omitempty"`
 
 	Value apiextensionsv1.JSON `json:"value,omitempty"`
+
+	// Resource is a complete Kubernetes object.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	// +nullable
+	Resource runtime.RawExtension `json:"resource,omitempty"`
 }
 
 // Underlying tests that Underlying1's underlying type is Underlying2 instead of string.
@@ -68,6 +75,7 @@ type Underlying struct {
 }
 
 // Underlying1 has an underlying type with an underlying type
+// +deprecated:replacement=Underlying2
 type Underlying1 Underlying2
 
 // Underlying2 is a string alias
@@ -85,16 +93,23 @@ type Rating int
 
 // GuestbookSpec defines the desired state of Guestbook.
 // +kubebuilder:validation:XValidation:rule="self.page < 200", message="Please start a new book."
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.digest) || has(self.digest)", message="digest cannot be removed once set"
 type GuestbookSpec struct {
 	// Page indicates the page number
 	// +default=1
 	// +kubebuilder:example=3
 	Page *PositiveInt `json:"page,omitempty"`
 	// Entries contain guest book entries for the page
+	// +listType=map
+	// +listMapKey=name
+	// +crd-ref-docs:maturity=beta
 	Entries []GuestbookEntry `json:"entries,omitempty"`
 	// Selector selects something
 	Selector metav1.LabelSelector `json:"selector,omitempty"`
 	// Headers contains a list of header items to include in the page
+	// +crd-ref-docs:example
+	// - Welcome
+	// - Sign here
 	// +kubebuilder:validation:MaxItems=10
 	// +kubebuilder:validation:UniqueItems=true
 	Headers []GuestbookHeader `json:"headers,omitempty"`
@@ -102,8 +117,11 @@ type GuestbookSpec struct {
 	CertificateRef gwapiv1b1.SecretObjectReference `json:"certificateRef"`
 	String         common.CommonString             `json:"str"`
 	// Enumeration is an example of an aliased enumeration type
+	// +kubebuilder:default=MyFirstValue
 	Enumeration MyEnum `json:"enum"`
 	// Digest is the content-addressable identifier of the guestbook
+	// +featureGate=ContentDigest
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="digest is immutable"
 	// +kubebuilder:validation:Pattern=`^sha256:[a-fA-F0-9]{64}$`
 	Digest string `json:"digest,omitempty"`
 }
@@ -119,9 +137,14 @@ const (
 )
 
 // +kubebuilder:validation:Minimum=1
+// +crd-ref-docs:title=Positive integer
 type PositiveInt int
 
 // GuestbookEntry defines an entry in a guest book. See https://example.com/old-page for more.
+// +crd-ref-docs:example
+// name: alice
+// tags: [guest]
+// rating: 5
 type GuestbookEntry struct {
 	// Name of the guest (pipe | should be escaped). See https://example.com/old-page for naming guidance.
 	// +kubebuilder:validation:Required
@@ -129,7 +152,9 @@ type GuestbookEntry struct {
 	// +kubebuilder:validation:Pattern=`0*[a-z0-9]*[a-z]*[0-9]`
 	Name string `json:"name,omitempty"`
 	// Tags of the entry.
+	// +listType=set
 	// +kubebuilder:validation:items:Pattern=`[a-z]*`
+	// +kubebuilder:default={guest, visitor}
 	Tags []string `json:"tags"`
 	// Time of entry
 	Time metav1.Time `json:"time,omitempty"`
@@ -150,18 +175,32 @@ type GuestbookEntry struct {
 
 	// Email is the email address of the guest (required field using +required marker)
 	// +required
+	// +crd-ref-docs:group=Contact details
+	// +crd-ref-docs:title="Email address"
 	Email string `json:"email"`
 	// Location is the location of the guest (required field using +k8s:required marker)
 	// +k8s:required
 	Location string `json:"location"`
 	// Phone is the phone number of the guest (optional field using +optional marker)
 	// +optional
+	// +crd-ref-docs:group=Contact details
 	Phone string `json:"phone"`
 	// Company is the company of the guest (optional field using +k8s:optional marker)
+	//
+	// Deprecated: companies are no longer recorded. Use Email instead.
 	// +k8s:optional
 	Company string `json:"company"`
+	// Moderation notes are for internal use only.
+	// +crd-ref-docs:hide
+	ModerationNotes string `json:"moderationNotes,omitempty"`
+	// Moderation is the state of the moderation of the entry.
+	Moderation ModerationState `json:"moderation,omitempty"`
 }
 
+// ModerationState is the state of the moderation of an entry, for internal use only.
+// +crd-ref-docs:hide
+type ModerationState string
+
 // GuestbookStatus defines the observed state of Guestbook.
 type GuestbookStatus struct {
 	// +kubebuilder:validation:Enum={OK, Error}
@@ -173,6 +212,8 @@ type GuestbookStatus struct {
 type Status string
 
 // GuestbookHeaders are strings to include at the top of a page.
+// +crd-ref-docs:maturity=beta
+// +crd-ref-docs:description="A header is a line of text shown at the top of a page."
 type GuestbookHeader string
 
 //+kubebuilder:object:root=true
@@ -119,6 +119,7 @@ func (in *Embedded4) DeepCopy() *Embedded4 {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedX) DeepCopyInto(out *EmbeddedX) {
 	*out = *in
+	in.Resource.DeepCopyInto(&out.Resource)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedX.
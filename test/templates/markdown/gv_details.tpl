@@ -4,8 +4,7 @@
 ## {{ $gv.GroupVersionString }}
 
 {{ markdownRewriteLinks $gv.Doc }}
-
-{{- if index $gv.Markers "special" }}
+{{ if $gv.HasMarker "special" }}
 *Important: This package is special and should be treated differently.*
 {{- end }}
 
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Deprecation describes why a field or type is deprecated and what replaces it.
+type Deprecation struct {
+	// Message is the explanation of the deprecation, e.g. the text of the "Deprecated:" paragraph of the doc.
+	Message string `json:"message,omitempty"`
+	// Replacement is the name of the field or type to use instead, if known.
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// deprecatedPrefix starts the paragraph of a doc comment describing a deprecation, following the Go convention.
+const deprecatedPrefix = "Deprecated:"
+
+var replacementRegex = regexp.MustCompile("(?i)\\buse\\s+`?([A-Za-z_][\\w.]*)`?(?:\\s+field|\\s+type)?\\s+instead\\b")
+
+// String describes the deprecation, e.g. "Deprecated: no longer used. Use `spec.rating` instead.".
+func (d *Deprecation) String() string {
+	s := "Deprecated"
+	if d.Message != "" {
+		s += ": " + d.Message
+	} else {
+		s += "."
+	}
+	if d.Replacement != "" && !strings.Contains(d.Message, d.Replacement) {
+		if !strings.HasSuffix(s, ".") {
+			s += "."
+		}
+		s += " Use `" + d.Replacement + "` instead."
+	}
+	return s
+}
+
+// ParseDeprecation returns the deprecation described by the "Deprecated:" paragraph of the doc, if any, and the doc
+// without that paragraph. The replacement is found in sentences such as "Use NewField instead.".
+func ParseDeprecation(doc string) (*Deprecation, string) {
+	lines := strings.Split(doc, "\n")
+	start := -1
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), deprecatedPrefix) && (i == 0 || strings.TrimSpace(lines[i-1]) == "") {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil, doc
+	}
+
+	end := start + 1
+	for end < len(lines) && strings.TrimSpace(lines[end]) != "" {
+		end++
+	}
+
+	paragraph := make([]string, 0, end-start)
+	for _, line := range lines[start:end] {
+		paragraph = append(paragraph, strings.TrimSpace(line))
+	}
+	message := strings.TrimSpace(strings.TrimPrefix(strings.Join(paragraph, " "), deprecatedPrefix))
+
+	// drop the empty line separating the paragraph from the next one
+	next := end
+	if next < len(lines) {
+		next++
+	}
+	rest := append(append([]string{}, lines[:start]...), lines[next:]...)
+	return &Deprecation{Message: message, Replacement: findReplacement(message)}, strings.Trim(strings.Join(rest, "\n"), "\n")
+}
+
+func findReplacement(message string) string {
+	if m := replacementRegex.FindStringSubmatch(message); m != nil {
+		return strings.TrimSuffix(m[1], ".")
+	}
+	return ""
+}
+
+// Deprecated reports whether the type is deprecated.
+func (t *Type) Deprecated() bool {
+	return t.Deprecation != nil
+}
+
+// Deprecated reports whether the field is deprecated.
+func (f *Field) Deprecated() bool {
+	return f.Deprecation != nil
+}
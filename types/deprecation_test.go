@@ -0,0 +1,49 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeprecation(t *testing.T) {
+	d, doc := ParseDeprecation("Company of the guest.\n\nDeprecated: companies are no longer\nrecorded. Use `spec.email` instead.\n\nMore details.")
+	require.Equal(t, &Deprecation{Message: "companies are no longer recorded. Use `spec.email` instead.", Replacement: "spec.email"}, d)
+	require.Equal(t, "Company of the guest.\n\nMore details.", doc)
+
+	d, doc = ParseDeprecation("Deprecated: use the Rating field instead.")
+	require.Equal(t, &Deprecation{Message: "use the Rating field instead."}, d)
+	require.Equal(t, "", doc)
+
+	d, doc = ParseDeprecation("Deprecated: Use NewField instead.")
+	require.Equal(t, "NewField", d.Replacement)
+	require.Equal(t, "", doc)
+
+	d, doc = ParseDeprecation("Not Deprecated: only a mention.")
+	require.Nil(t, d)
+	require.Equal(t, "Not Deprecated: only a mention.", doc)
+}
+
+func TestDeprecationString(t *testing.T) {
+	require.Equal(t, "Deprecated.", (&Deprecation{}).String())
+	require.Equal(t, "Deprecated. Use `rating` instead.", (&Deprecation{Replacement: "rating"}).String())
+	require.Equal(t, "Deprecated: no longer used. Use `rating` instead.", (&Deprecation{Message: "no longer used", Replacement: "rating"}).String())
+	require.Equal(t, "Deprecated: use rating instead.", (&Deprecation{Message: "use rating instead.", Replacement: "rating"}).String())
+}
+
+func TestExcludeDeprecated(t *testing.T) {
+	old := &Type{UID: "Old", Name: "Old", Kind: StructKind, Deprecation: &Deprecation{}}
+	nested := &Type{UID: "Nested", Name: "Nested", Kind: StructKind}
+	old.Fields = Fields{{Name: "nested", Type: nested}}
+	root := &Type{UID: "Root", Name: "Root", Kind: StructKind, Fields: Fields{
+		{Name: "old", Type: old},
+		{Name: "legacy", Type: &Type{Name: "string", Kind: BasicKind}, Deprecation: &Deprecation{}},
+		{Name: "name", Type: &Type{Name: "string", Kind: BasicKind}},
+	}}
+	old.References = []*Type{root}
+	nested.References = []*Type{old}
+	types := TypeMap{"Old": old, "Nested": nested, "Root": root}
+
+	require.ElementsMatch(t, []string{"Old", "Nested"}, types.Exclude((*Type).Deprecated, (*Field).Deprecated))
+	require.Equal(t, Fields{{Name: "name", Type: &Type{Name: "string", Kind: BasicKind}}}, root.Fields)
+}
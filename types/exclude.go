@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+// Exclude removes the types and fields matching the given functions, along with the fields referring to removed types,
+// the types used only by removed types and fields, and the references they make. It returns the keys of the removed
+// types.
+func (types TypeMap) Exclude(excludeType func(*Type) bool, excludeField func(*Field) bool) []string {
+	var removed []string
+	var removedTypes []*Type
+	for key, t := range types {
+		if excludeType(t) {
+			removed = append(removed, key)
+			removedTypes = append(removedTypes, t)
+			delete(types, key)
+		}
+	}
+
+	referenced := make(map[string]bool, len(types))
+	allFields := make(map[string]Fields, len(types))
+	for key, t := range types {
+		referenced[key] = len(t.References) > 0
+		allFields[t.UID] = t.Fields
+		var fields Fields
+		for _, f := range t.Fields {
+			if !excludeField(f) && !refersToAny(f.Type, removedTypes) {
+				fields = append(fields, f)
+			}
+		}
+		t.Fields = fields
+	}
+
+	for _, t := range types {
+		var references []*Type
+		for _, parent := range t.References {
+			if _, ok := types[parent.UID]; !ok {
+				continue
+			}
+			// keep the references not explained by the fields of the parent, e.g. for inlined types
+			if refersThroughFields(allFields[parent.UID], t) && !refersThroughFields(parent.Fields, t) {
+				continue
+			}
+			references = append(references, parent)
+		}
+		t.References = references
+	}
+
+	// remove the types used only by removed types and fields
+	for orphaned := true; orphaned; {
+		orphaned = false
+		for key, t := range types {
+			if t.GVK == nil && referenced[key] && len(t.References) == 0 {
+				removed = append(removed, key)
+				delete(types, key)
+				orphaned = true
+			}
+		}
+		for _, t := range types {
+			var references []*Type
+			for _, parent := range t.References {
+				if _, ok := types[parent.UID]; ok {
+					references = append(references, parent)
+				}
+			}
+			t.References = references
+		}
+	}
+	return removed
+}
+
+func refersThroughFields(fields Fields, t *Type) bool {
+	for _, f := range fields {
+		if Refers(f.Type, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func refersToAny(ref *Type, types []*Type) bool {
+	for _, t := range types {
+		if Refers(ref, t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+// FieldGroup is a set of fields sharing the same +crd-ref-docs:group marker.
+type FieldGroup struct {
+	Name   string
+	Fields Fields
+}
+
+// TypeGroup is a set of types sharing the same +crd-ref-docs:group marker.
+type TypeGroup struct {
+	Name  string
+	Types []*Type
+}
+
+// PackageGroup is a set of group versions whose packages share the same +crd-ref-docs:group marker.
+type PackageGroup struct {
+	Name          string
+	GroupVersions []GroupVersionDetails
+}
+
+// FieldGroups returns the members of the type in the given order, grouped by DocGroup. Fields without a group come
+// first, followed by the groups in order of first appearance.
+func (t *Type) FieldGroups(order FieldOrder) []FieldGroup {
+	var groups []FieldGroup
+	index := make(map[string]int)
+	add := func(f *Field) {
+		i, ok := index[f.DocGroup]
+		if !ok {
+			i = len(groups)
+			index[f.DocGroup] = i
+			groups = append(groups, FieldGroup{Name: f.DocGroup})
+		}
+		groups[i].Fields = append(groups[i].Fields, f)
+	}
+
+	members := t.SortedMembers(order)
+	for _, f := range members {
+		if f.DocGroup == "" {
+			add(f)
+		}
+	}
+	for _, f := range members {
+		if f.DocGroup != "" {
+			add(f)
+		}
+	}
+
+	return groups
+}
+
+// TypeGroups returns the types of the group version in the given order, grouped by DocGroup. Types without a group
+// come first, followed by the groups in order of first appearance.
+func (gvd GroupVersionDetails) TypeGroups(order TypeOrder) []TypeGroup {
+	var groups []TypeGroup
+	index := make(map[string]int)
+	add := func(t *Type) {
+		i, ok := index[t.DocGroup]
+		if !ok {
+			i = len(groups)
+			index[t.DocGroup] = i
+			groups = append(groups, TypeGroup{Name: t.DocGroup})
+		}
+		groups[i].Types = append(groups[i].Types, t)
+	}
+
+	typeList := gvd.OrderedTypes(order)
+	for _, t := range typeList {
+		if t.DocGroup == "" {
+			add(t)
+		}
+	}
+	for _, t := range typeList {
+		if t.DocGroup != "" {
+			add(t)
+		}
+	}
+
+	return groups
+}
+
+// GroupPackages groups the group versions by DocGroup. Group versions without a group come first, followed by the
+// groups in order of first appearance.
+func GroupPackages(gvds []GroupVersionDetails) []PackageGroup {
+	var groups []PackageGroup
+	index := make(map[string]int)
+	add := func(gvd GroupVersionDetails) {
+		i, ok := index[gvd.DocGroup]
+		if !ok {
+			i = len(groups)
+			index[gvd.DocGroup] = i
+			groups = append(groups, PackageGroup{Name: gvd.DocGroup})
+		}
+		groups[i].GroupVersions = append(groups[i].GroupVersions, gvd)
+	}
+
+	for _, gvd := range gvds {
+		if gvd.DocGroup == "" {
+			add(gvd)
+		}
+	}
+	for _, gvd := range gvds {
+		if gvd.DocGroup != "" {
+			add(gvd)
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+)
+
+func TestFieldGroups(t *testing.T) {
+	typ := &Type{Kind: StructKind, Fields: Fields{
+		{Name: "phone", DocGroup: "Contact"},
+		{Name: "name"},
+		{Name: "retries", DocGroup: "Advanced"},
+		{Name: "email", DocGroup: "Contact"},
+		{Name: "age"},
+	}}
+
+	groups := typ.FieldGroups(FieldOrderSource)
+	require.Len(t, groups, 3)
+	require.Equal(t, "", groups[0].Name)
+	require.Equal(t, []string{"name", "age"}, fieldNames(groups[0].Fields))
+	require.Equal(t, "Contact", groups[1].Name)
+	require.Equal(t, []string{"phone", "email"}, fieldNames(groups[1].Fields))
+	require.Equal(t, "Advanced", groups[2].Name)
+	require.Equal(t, []string{"retries"}, fieldNames(groups[2].Fields))
+
+	groups = typ.FieldGroups(FieldOrderAlphabetical)
+	require.Len(t, groups, 3)
+	require.Equal(t, []string{"age", "name"}, fieldNames(groups[0].Fields))
+	require.Equal(t, "Contact", groups[1].Name)
+	require.Equal(t, []string{"email", "phone"}, fieldNames(groups[1].Fields))
+
+	require.Empty(t, (&Type{Kind: StructKind}).FieldGroups(FieldOrderSource))
+}
+
+func TestTypeGroups(t *testing.T) {
+	gvd := GroupVersionDetails{Types: TypeMap{
+		"Spec":    {Name: "Spec", DocGroup: "Core"},
+		"Status":  {Name: "Status", DocGroup: "Core"},
+		"Backoff": {Name: "Backoff"},
+	}}
+
+	groups := gvd.TypeGroups(TypeOrderAlphabetical)
+	require.Len(t, groups, 2)
+	require.Equal(t, "", groups[0].Name)
+	require.Equal(t, []string{"Backoff"}, typeNames(groups[0].Types))
+	require.Equal(t, "Core", groups[1].Name)
+	require.Equal(t, []string{"Spec", "Status"}, typeNames(groups[1].Types))
+}
+
+func TestGroupPackages(t *testing.T) {
+	gvds := []GroupVersionDetails{
+		{GroupVersion: schema.GroupVersion{Group: "a.example.com", Version: "v1"}, DocGroup: "Extensions"},
+		{GroupVersion: schema.GroupVersion{Group: "b.example.com", Version: "v1"}},
+		{GroupVersion: schema.GroupVersion{Group: "c.example.com", Version: "v1"}, DocGroup: "Extensions"},
+	}
+
+	groups := GroupPackages(gvds)
+	require.Len(t, groups, 2)
+	require.Equal(t, "", groups[0].Name)
+	require.Equal(t, []GroupVersionDetails{gvds[1]}, groups[0].GroupVersions)
+	require.Equal(t, "Extensions", groups[1].Name)
+	require.Equal(t, []GroupVersionDetails{gvds[0], gvds[2]}, groups[1].GroupVersions)
+}
+
+func TestDisplayName(t *testing.T) {
+	require.Equal(t, "Spec", (&Type{Name: "Spec"}).DisplayName())
+	require.Equal(t, "Specification", (&Type{Name: "Spec", Title: "Specification"}).DisplayName())
+
+	gvd := GroupVersionDetails{GroupVersion: schema.GroupVersion{Group: "example.com", Version: "v1"}}
+	require.Equal(t, "example.com/v1", gvd.DisplayName())
+	gvd.Title = "Example API"
+	require.Equal(t, "Example API", gvd.DisplayName())
+}
+
+func TestPackageMarkers(t *testing.T) {
+	gvd := GroupVersionDetails{Markers: markers.MarkerValues{
+		GroupNameMarker:       {"example.com"},
+		PackageOptionalMarker: {struct{}{}},
+		"special":             {struct{}{}},
+	}}
+	require.Equal(t, "example.com", gvd.GroupName())
+	require.Equal(t, "", gvd.VersionName())
+	require.False(t, gvd.Skipped())
+	require.True(t, gvd.FieldsOptionalByDefault())
+	require.True(t, gvd.HasMarker("special"))
+	require.False(t, gvd.HasMarker("other"))
+	require.Equal(t, "", gvd.StringMarker("special"))
+
+	gvd.Markers[PackageRequiredMarker] = []any{struct{}{}}
+	gvd.Markers[SkipMarker] = []any{struct{}{}}
+	require.False(t, gvd.FieldsOptionalByDefault())
+	require.True(t, gvd.Skipped())
+}
@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Maturity is the maturity level of a field or type, set with the +crd-ref-docs:maturity marker.
+type Maturity string
+
+const (
+	MaturityAlpha  Maturity = "alpha"
+	MaturityBeta   Maturity = "beta"
+	MaturityStable Maturity = "stable"
+)
+
+// ParseMaturity returns the maturity level of the given name, case-insensitively. An empty name is stable.
+func ParseMaturity(name string) (Maturity, error) {
+	switch m := Maturity(strings.ToLower(strings.TrimSpace(name))); m {
+	case MaturityAlpha, MaturityBeta, MaturityStable:
+		return m, nil
+	case "":
+		return MaturityStable, nil
+	default:
+		return "", fmt.Errorf("unknown maturity %q", name)
+	}
+}
+
+// Below reports whether m is less mature than level. The empty level is stable.
+func (m Maturity) Below(level Maturity) bool {
+	return m.rank() < level.rank()
+}
+
+func (m Maturity) rank() int {
+	switch m {
+	case MaturityAlpha:
+		return 0
+	case MaturityBeta:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Badges returns the labels describing the maturity and the feature gate of the type, e.g. "Alpha" and
+// "Feature gate: Foo". Stable types have no maturity badge.
+func (t *Type) Badges() []string {
+	return badges(t.Maturity, t.FeatureGate)
+}
+
+// Badges returns the labels describing the maturity and the feature gate of the field.
+func (f *Field) Badges() []string {
+	return badges(f.Maturity, f.FeatureGate)
+}
+
+func badges(m Maturity, featureGate string) []string {
+	var labels []string
+	if m != "" && m != MaturityStable {
+		labels = append(labels, strings.ToUpper(string(m[:1]))+string(m[1:]))
+	}
+	if featureGate != "" {
+		labels = append(labels, "Feature gate: "+featureGate)
+	}
+	return labels
+}
+
+// Refers reports whether the value of a field of type ref contains values of type t, through pointers, slices and
+// maps.
+func Refers(ref, t *Type) bool {
+	if ref == nil {
+		return false
+	}
+
+	switch ref.Kind {
+	case SliceKind, PointerKind:
+		return Refers(ref.UnderlyingType, t)
+	case MapKind:
+		return Refers(ref.KeyType, t) || Refers(ref.ValueType, t)
+	default:
+		return ref.UID == t.UID
+	}
+}
+
+// ExcludeBelow removes the types and fields less mature than level, along with the references they make, and returns
+// the keys of the removed types.
+func (types TypeMap) ExcludeBelow(level Maturity) []string {
+	return types.Exclude(
+		func(t *Type) bool { return t.Maturity.Below(level) },
+		func(f *Field) bool { return f.Maturity.Below(level) },
+	)
+}
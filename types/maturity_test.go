@@ -0,0 +1,60 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaturity(t *testing.T) {
+	m, err := ParseMaturity(" Alpha ")
+	require.NoError(t, err)
+	require.Equal(t, MaturityAlpha, m)
+
+	m, err = ParseMaturity("")
+	require.NoError(t, err)
+	require.Equal(t, MaturityStable, m)
+
+	_, err = ParseMaturity("ga")
+	require.Error(t, err)
+
+	require.True(t, MaturityAlpha.Below(MaturityBeta))
+	require.False(t, MaturityStable.Below(""))
+	require.False(t, Maturity("").Below(MaturityStable))
+}
+
+func TestBadges(t *testing.T) {
+	require.Nil(t, (&Type{Maturity: MaturityStable}).Badges())
+	require.Equal(t, []string{"Alpha", "Feature gate: Foo"}, (&Field{Maturity: MaturityAlpha, FeatureGate: "Foo"}).Badges())
+}
+
+func newMaturityTypes() TypeMap {
+	entry := &Type{UID: "Entry", Name: "Entry", Kind: StructKind, Maturity: MaturityStable}
+	shared := &Type{UID: "Shared", Name: "Shared", Kind: StructKind, Maturity: MaturityStable}
+	spec := &Type{UID: "Spec", Name: "Spec", Kind: StructKind, Maturity: MaturityStable, Fields: Fields{
+		{Name: "entries", Type: &Type{Kind: SliceKind, UnderlyingType: entry}, Maturity: MaturityAlpha},
+		{Name: "shared", Type: &Type{Kind: PointerKind, UnderlyingType: shared}, Maturity: MaturityStable},
+		{Name: "beta", Type: shared, Maturity: MaturityBeta},
+	}}
+	root := &Type{UID: "Root", Name: "Root", Kind: StructKind, Maturity: MaturityStable, GVK: nil, Fields: Fields{
+		{Name: "spec", Type: spec, Maturity: MaturityStable},
+	}}
+	entry.References = []*Type{spec}
+	shared.References = []*Type{spec}
+	spec.References = []*Type{root}
+	return TypeMap{"Entry": entry, "Shared": shared, "Spec": spec, "Root": root}
+}
+
+func TestExcludeBelow(t *testing.T) {
+	types := newMaturityTypes()
+
+	// the types used only by removed fields are removed as well
+	require.Equal(t, []string{"Entry"}, types.ExcludeBelow(MaturityBeta))
+	require.NotContains(t, types, "Entry")
+	require.Len(t, types["Spec"].Fields, 2)
+	require.Equal(t, []*Type{types["Spec"]}, types["Shared"].References)
+
+	require.Empty(t, types.ExcludeBelow(MaturityStable))
+	require.Len(t, types["Spec"].Fields, 1)
+	require.Equal(t, []*Type{types["Spec"]}, types["Shared"].References)
+}
@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeSemantics describes how server-side apply merges the values of a field, as set by the listType, listMapKey,
+// mapType and structType markers.
+type MergeSemantics struct {
+	ListType    string   `json:"listType,omitempty"`
+	ListMapKeys []string `json:"listMapKeys,omitempty"`
+	MapType     string   `json:"mapType,omitempty"`
+	StructType  string   `json:"structType,omitempty"`
+}
+
+// String describes the merge semantics in prose, e.g. "list keyed by `name`".
+func (m MergeSemantics) String() string {
+	var parts []string
+
+	switch m.ListType {
+	case "map":
+		keys := make([]string, len(m.ListMapKeys))
+		for i, k := range m.ListMapKeys {
+			keys[i] = fmt.Sprintf("`%s`", k)
+		}
+		if len(keys) == 0 {
+			parts = append(parts, "list merged by key")
+		} else {
+			parts = append(parts, "list keyed by "+strings.Join(keys, ", "))
+		}
+	case "set":
+		parts = append(parts, "list of unique values, merged as a set")
+	case "atomic":
+		parts = append(parts, "atomic list, replaced as a whole")
+	}
+
+	switch m.MapType {
+	case "granular":
+		parts = append(parts, "map merged by key")
+	case "atomic":
+		parts = append(parts, "atomic map, replaced as a whole")
+	}
+
+	switch m.StructType {
+	case "granular":
+		parts = append(parts, "struct merged by field")
+	case "atomic":
+		parts = append(parts, "atomic struct, replaced as a whole")
+	}
+
+	return strings.Join(parts, "; ")
+}
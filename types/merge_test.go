@@ -0,0 +1,18 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSemanticsString(t *testing.T) {
+	require.Equal(t, "list keyed by `name`", MergeSemantics{ListType: "map", ListMapKeys: []string{"name"}}.String())
+	require.Equal(t, "list keyed by `containerPort`, `protocol`",
+		MergeSemantics{ListType: "map", ListMapKeys: []string{"containerPort", "protocol"}}.String())
+	require.Equal(t, "list of unique values, merged as a set", MergeSemantics{ListType: "set"}.String())
+	require.Equal(t, "atomic list, replaced as a whole", MergeSemantics{ListType: "atomic"}.String())
+	require.Equal(t, "atomic map, replaced as a whole", MergeSemantics{MapType: "atomic"}.String())
+	require.Equal(t, "struct merged by field", MergeSemantics{StructType: "granular"}.String())
+	require.Equal(t, "", MergeSemantics{}.String())
+}
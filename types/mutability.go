@@ -0,0 +1,99 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CELRule is a CEL validation rule set with the +kubebuilder:validation:XValidation marker.
+type CELRule struct {
+	Rule    string
+	Message string
+}
+
+// Mutability describes the restrictions on the updates of a field set with CEL transition rules.
+type Mutability struct {
+	// Immutable is set by the `self == oldSelf` rule of the field, or the `self.x == oldSelf.x` rule of its parent.
+	Immutable bool `json:"immutable,omitempty"`
+	// SetOnce is set by the `!has(oldSelf.x) || has(self.x)` rule of the parent of the field, or by rules whose
+	// message says that the field "cannot be removed once set".
+	SetOnce bool `json:"setOnce,omitempty"`
+}
+
+var (
+	selfEqualsOldSelfRegex   = regexp.MustCompile(`^\(?(?:self==oldSelf|oldSelf==self)\)?$`)
+	fieldEqualsOldFieldRegex = regexp.MustCompile(`^\(?self\.(\w+)==oldSelf\.(\w+)\)?$`)
+	fieldNotRemovedRegex     = regexp.MustCompile(`^\(?!has\(oldSelf\.(\w+)\)\|\|has\(self\.(\w+)\)\)?$`)
+	hasSelfFieldRegex        = regexp.MustCompile(`has\(self\.(\w+)\)`)
+	selfFieldRegex           = regexp.MustCompile(`\bself\.\w`)
+)
+
+// setOnceMessage is the usual message of the rules preventing the removal of a field.
+const setOnceMessage = "cannot be removed once set"
+
+// Notes describes the restrictions, e.g. "immutable: cannot be changed once set".
+func (m Mutability) Notes() []string {
+	var notes []string
+	if m.Immutable {
+		notes = append(notes, "immutable: cannot be changed once set")
+	}
+	if m.SetOnce {
+		notes = append(notes, "set-once: cannot be removed once set")
+	}
+	return notes
+}
+
+// FieldMutability returns the restrictions on the updates of the field with the given JSON name, set by the
+// transition rules of the field and of its parent type.
+func FieldMutability(name string, fieldRules, parentRules []CELRule) Mutability {
+	var m Mutability
+	for _, r := range fieldRules {
+		rule := normalizeRule(r.Rule)
+		if selfEqualsOldSelfRegex.MatchString(rule) {
+			m.Immutable = true
+		}
+		// the rules of struct types accessing their fields are about the fields rather than the value itself
+		if strings.Contains(strings.ToLower(r.Message), setOnceMessage) && !selfFieldRegex.MatchString(rule) {
+			m.SetOnce = true
+		}
+	}
+
+	for _, r := range parentRules {
+		rule := normalizeRule(r.Rule)
+		if match := fieldEqualsOldFieldRegex.FindStringSubmatch(rule); match != nil && match[1] == name && match[2] == name {
+			m.Immutable = true
+		}
+		if match := fieldNotRemovedRegex.FindStringSubmatch(rule); match != nil && match[1] == name && match[2] == name {
+			m.SetOnce = true
+		}
+		if strings.Contains(strings.ToLower(r.Message), setOnceMessage) {
+			for _, match := range hasSelfFieldRegex.FindAllStringSubmatch(rule, -1) {
+				if match[1] == name {
+					m.SetOnce = true
+				}
+			}
+		}
+	}
+	return m
+}
+
+// normalizeRule removes the whitespace of a rule, so that it can be matched regardless of its formatting.
+func normalizeRule(rule string) string {
+	return strings.Join(strings.Fields(rule), "")
+}
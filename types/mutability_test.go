@@ -0,0 +1,68 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldMutability(t *testing.T) {
+	tests := []struct {
+		name        string
+		fieldRules  []CELRule
+		parentRules []CELRule
+		want        Mutability
+	}{
+		{
+			name: "no rules",
+		},
+		{
+			name:       "immutable field",
+			fieldRules: []CELRule{{Rule: "self == oldSelf", Message: "name is immutable"}},
+			want:       Mutability{Immutable: true},
+		},
+		{
+			name:        "immutable field of parent",
+			parentRules: []CELRule{{Rule: "self.name == oldSelf.name"}},
+			want:        Mutability{Immutable: true},
+		},
+		{
+			name:        "set-once field of parent",
+			parentRules: []CELRule{{Rule: "!has(oldSelf.name) || has(self.name)"}},
+			want:        Mutability{SetOnce: true},
+		},
+		{
+			name:        "set-once message of parent",
+			parentRules: []CELRule{{Rule: "has(oldSelf.name) ? has(self.name) : true", Message: "name cannot be removed once set"}},
+			want:        Mutability{SetOnce: true},
+		},
+		{
+			name:       "set-once message of field",
+			fieldRules: []CELRule{{Rule: "oldSelf == '' || self != ''", Message: "Cannot be removed once set"}},
+			want:       Mutability{SetOnce: true},
+		},
+		{
+			name:       "rules of the fields of a struct",
+			fieldRules: []CELRule{{Rule: "!has(oldSelf.other) || has(self.other)", Message: "other cannot be removed once set"}},
+		},
+		{
+			name: "rules of other fields",
+			parentRules: []CELRule{
+				{Rule: "self.other == oldSelf.other"},
+				{Rule: "!has(oldSelf.other) || has(self.other)", Message: "other cannot be removed once set"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, FieldMutability("name", tt.fieldRules, tt.parentRules))
+		})
+	}
+}
+
+func TestMutabilityNotes(t *testing.T) {
+	require.Empty(t, Mutability{}.Notes())
+	require.Equal(t, []string{"immutable: cannot be changed once set", "set-once: cannot be removed once set"},
+		Mutability{Immutable: true, SetOnce: true}.Notes())
+}
@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+import "sort"
+
+// StatusFieldName is the name of the field holding the observed state of a root kind.
+const StatusFieldName = "status"
+
+// ObservedStateSection lists the types describing the observed state of a kind.
+type ObservedStateSection struct {
+	Kind  string
+	Types []*Type
+}
+
+// ClassifyObservedState flags the types that are reachable only through the status field of root kinds as observed
+// state, recording the kinds they describe.
+func (types TypeMap) ClassifyObservedState() {
+	// types reachable from the status of a root kind
+	statusOf := make(map[*Type][]string)
+	for _, t := range types {
+		if t.GVK == nil {
+			continue
+		}
+		for _, f := range t.Fields {
+			if f.Name != StatusFieldName || f.Type == nil {
+				continue
+			}
+			visitObservedStateCandidates(f.Type, make(map[*Type]struct{}), func(child *Type) {
+				statusOf[child] = append(statusOf[child], t.GVK.Kind)
+			})
+		}
+	}
+
+	// types reachable through any other path
+	reachable := make(map[*Type]struct{})
+	for _, t := range types {
+		if _, ok := statusOf[t]; ok {
+			continue
+		}
+		visitObservedStateCandidates(t, reachable, func(*Type) {})
+	}
+
+	for t, kinds := range statusOf {
+		if _, ok := reachable[t]; ok {
+			continue
+		}
+		sort.Strings(kinds)
+		t.ObservedState = true
+		t.ObservedStateOf = kinds
+	}
+}
+
+// visitObservedStateCandidates calls visit for t and the types it references, without following the status fields of
+// root kinds.
+func visitObservedStateCandidates(t *Type, visited map[*Type]struct{}, visit func(t *Type)) {
+	if t == nil {
+		return
+	}
+	if _, ok := visited[t]; ok {
+		return
+	}
+	visited[t] = struct{}{}
+	visit(t)
+
+	for _, f := range t.Fields {
+		if t.GVK != nil && f.Name == StatusFieldName {
+			continue
+		}
+		visitObservedStateCandidates(f.Type, visited, visit)
+	}
+	visitObservedStateCandidates(t.UnderlyingType, visited, visit)
+	visitObservedStateCandidates(t.KeyType, visited, visit)
+	visitObservedStateCandidates(t.ValueType, visited, visit)
+}
+
+// ObservedStateKind returns the kind whose observed state section lists t: the first kind described by t whose
+// observed state is not omitted, as reported by omitted. It returns an empty string if all of them are omitted.
+func (t *Type) ObservedStateKind(omitted func(kind string) bool) string {
+	for _, kind := range t.ObservedStateOf {
+		if omitted == nil || !omitted(kind) {
+			return kind
+		}
+	}
+	return ""
+}
+
+// ObservedStateSections returns the observed state types of the group version in the given order, by kind. A type
+// describing the observed state of several kinds is listed once, with the first of them whose observed state is not
+// omitted (see ObservedStateKind), so that the links of the other kinds resolve. Sections are sorted by kind.
+func (gvd GroupVersionDetails) ObservedStateSections(order TypeOrder, omitted func(kind string) bool) []ObservedStateSection {
+	var sections []ObservedStateSection
+	index := make(map[string]int)
+	for _, t := range gvd.OrderedTypes(order) {
+		if !t.ObservedState {
+			continue
+		}
+		kind := t.ObservedStateKind(omitted)
+		if kind == "" {
+			continue
+		}
+		i, ok := index[kind]
+		if !ok {
+			i = len(sections)
+			index[kind] = i
+			sections = append(sections, ObservedStateSection{Kind: kind})
+		}
+		sections[i].Types = append(sections[i].Types, t)
+	}
+
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].Kind < sections[j].Kind
+	})
+
+	return sections
+}
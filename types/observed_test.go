@@ -0,0 +1,74 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClassifyObservedState(t *testing.T) {
+	str := &Type{UID: "string", Name: "string", Kind: BasicKind}
+	condition := &Type{UID: "Condition", Name: "Condition", Kind: StructKind, Fields: Fields{{Name: "reason", Type: str}}}
+	conditions := &Type{UID: "[]Condition", Name: "Condition", Kind: SliceKind, UnderlyingType: condition}
+	phase := &Type{UID: "Phase", Name: "Phase", Kind: AliasKind, UnderlyingType: str}
+	shared := &Type{UID: "Shared", Name: "Shared", Kind: StructKind}
+	bookStatus := &Type{UID: "BookStatus", Name: "BookStatus", Kind: StructKind, Fields: Fields{
+		{Name: "conditions", Type: conditions},
+		{Name: "phase", Type: phase},
+		{Name: "shared", Type: shared},
+	}}
+	shelfStatus := &Type{UID: "ShelfStatus", Name: "ShelfStatus", Kind: StructKind, Fields: Fields{{Name: "conditions", Type: conditions}}}
+	bookSpec := &Type{UID: "BookSpec", Name: "BookSpec", Kind: StructKind, Fields: Fields{{Name: "shared", Type: shared}}}
+	book := &Type{UID: "Book", Name: "Book", Kind: StructKind, GVK: &schema.GroupVersionKind{Kind: "Book"}, Fields: Fields{
+		{Name: "spec", Type: bookSpec},
+		{Name: "status", Type: bookStatus},
+	}}
+	shelf := &Type{UID: "Shelf", Name: "Shelf", Kind: StructKind, GVK: &schema.GroupVersionKind{Kind: "Shelf"}, Fields: Fields{
+		{Name: "books", Type: &Type{UID: "[]Book", Name: "Book", Kind: SliceKind, UnderlyingType: book}},
+		{Name: "status", Type: shelfStatus},
+	}}
+
+	typeMap := TypeMap{}
+	for _, typ := range []*Type{str, condition, conditions, phase, shared, bookStatus, shelfStatus, bookSpec, book, shelf} {
+		typeMap[typ.UID] = typ
+	}
+	typeMap.ClassifyObservedState()
+
+	require.True(t, bookStatus.ObservedState)
+	require.Equal(t, []string{"Book"}, bookStatus.ObservedStateOf)
+	require.True(t, phase.ObservedState)
+	require.True(t, condition.ObservedState)
+	require.Equal(t, []string{"Book", "Shelf"}, condition.ObservedStateOf)
+	require.True(t, shelfStatus.ObservedState)
+
+	// also reachable through the spec
+	require.False(t, shared.ObservedState)
+	require.False(t, bookSpec.ObservedState)
+	require.False(t, book.ObservedState)
+
+	gvd := GroupVersionDetails{Types: TypeMap{
+		"Book":        book,
+		"BookSpec":    bookSpec,
+		"BookStatus":  bookStatus,
+		"Condition":   condition,
+		"Phase":       phase,
+		"Shelf":       shelf,
+		"ShelfStatus": shelfStatus,
+	}}
+	sections := gvd.ObservedStateSections(TypeOrderAlphabetical, nil)
+	require.Len(t, sections, 2)
+	require.Equal(t, "Book", sections[0].Kind)
+	require.Equal(t, []string{"BookStatus", "Condition", "Phase"}, typeNames(sections[0].Types))
+	require.Equal(t, "Shelf", sections[1].Kind)
+	require.Equal(t, []string{"ShelfStatus"}, typeNames(sections[1].Types))
+
+	// the types shared with an omitted kind are listed with the next kind
+	omitBook := func(kind string) bool { return kind == "Book" }
+	require.Equal(t, "Shelf", condition.ObservedStateKind(omitBook))
+	require.Equal(t, "", phase.ObservedStateKind(omitBook))
+	sections = gvd.ObservedStateSections(TypeOrderAlphabetical, omitBook)
+	require.Len(t, sections, 1)
+	require.Equal(t, "Shelf", sections[0].Kind)
+	require.Equal(t, []string{"Condition", "ShelfStatus"}, typeNames(sections[0].Types))
+}
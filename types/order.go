@@ -0,0 +1,171 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+import (
+	"sort"
+
+	"sigs.k8s.io/controller-tools/pkg/markers"
+)
+
+// OrderMarker is the field marker setting the weight of a field when fields are ordered by marker
+// (e.g. +docs:order=10). Fields are sorted by increasing weight, and fields without the marker weigh 0.
+const OrderMarker = "docs:order"
+
+// FieldOrder is a strategy to order the fields of a type.
+type FieldOrder string
+
+const (
+	// FieldOrderSource keeps the fields in declaration order, with inlined fields in place of the inlined type.
+	FieldOrderSource FieldOrder = "source"
+	// FieldOrderAlphabetical sorts the fields by name.
+	FieldOrderAlphabetical FieldOrder = "alphabetical"
+	// FieldOrderRequiredFirst moves the required fields before the optional ones, keeping the declaration order
+	// otherwise.
+	FieldOrderRequiredFirst FieldOrder = "required-first"
+	// FieldOrderMarker sorts the fields by the weight set with the OrderMarker.
+	FieldOrderMarker FieldOrder = "marker"
+)
+
+// TypeOrder is a strategy to order the types of a group version.
+type TypeOrder string
+
+const (
+	// TypeOrderAlphabetical sorts the types by name.
+	TypeOrderAlphabetical TypeOrder = "alphabetical"
+	// TypeOrderSource keeps the types in declaration order.
+	TypeOrderSource TypeOrder = "source"
+	// TypeOrderKindsFirst lists each root kind followed by the types it depends on, then the remaining types by name,
+	// each followed by the types it depends on.
+	TypeOrderKindsFirst TypeOrder = "kinds-first"
+)
+
+// RequiredField reports whether a field is required in the CRD schema, following controller-gen: the Required and
+// Optional markers of the field take precedence, otherwise fields that are neither inlined nor tagged omitempty are
+// required, unless their package is optional by default (see GroupVersionDetails.FieldsOptionalByDefault).
+func RequiredField(values markers.MarkerValues, inline, omitEmpty, optionalByDefault bool) bool {
+	switch {
+	case values.Get("kubebuilder:validation:Optional") != nil:
+		return false
+	case values.Get("kubebuilder:validation:Required") != nil:
+		return true
+	case values.Get("optional") != nil, values.Get("k8s:optional") != nil:
+		return false
+	case values.Get("required") != nil, values.Get("k8s:required") != nil:
+		return true
+	default:
+		return !optionalByDefault && !inline && !omitEmpty
+	}
+}
+
+// orderWeight returns the weight of the field set with the OrderMarker.
+func (f *Field) orderWeight() int {
+	if weight, ok := f.Markers.Get(OrderMarker).(int); ok {
+		return weight
+	}
+	return 0
+}
+
+// SortedMembers returns the members of the type in the given order. Source order is used when order is empty.
+func (t *Type) SortedMembers(order FieldOrder) Fields {
+	members := t.Members()
+	if len(members) <= 1 {
+		return members
+	}
+
+	var less func(a, b *Field) bool
+	switch order {
+	case FieldOrderAlphabetical:
+		less = func(a, b *Field) bool { return a.Name < b.Name }
+	case FieldOrderRequiredFirst:
+		less = func(a, b *Field) bool { return a.Required && !b.Required }
+	case FieldOrderMarker:
+		less = func(a, b *Field) bool { return a.orderWeight() < b.orderWeight() }
+	default:
+		return members
+	}
+
+	sorted := make(Fields, len(members))
+	copy(sorted, members)
+	sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+
+	return sorted
+}
+
+// OrderedTypes returns the types of the group version in the given order. Alphabetical order is used when order is
+// empty.
+func (gvd GroupVersionDetails) OrderedTypes(order TypeOrder) []*Type {
+	switch order {
+	case TypeOrderSource:
+		typeList := gvd.SortedTypes()
+		sort.SliceStable(typeList, func(i, j int) bool { return typeList[i].Order < typeList[j].Order })
+		return typeList
+	case TypeOrderKindsFirst:
+		return gvd.kindsFirstTypes()
+	default:
+		return gvd.SortedTypes()
+	}
+}
+
+// kindsFirstTypes lists each root kind followed by the types of the group version it depends on, depth first in
+// field order, then the remaining types by name in the same way.
+func (gvd GroupVersionDetails) kindsFirstTypes() []*Type {
+	inGroupVersion := make(map[string]*Type, len(gvd.Types))
+	for _, t := range gvd.Types {
+		inGroupVersion[Identifier(t)] = t
+	}
+
+	typeList := make([]*Type, 0, len(gvd.Types))
+	visited := make(map[string]bool, len(gvd.Types))
+	var visit func(t *Type)
+	visit = func(t *Type) {
+		if t == nil {
+			return
+		}
+
+		switch t.Kind {
+		case PointerKind, SliceKind:
+			visit(t.UnderlyingType)
+			return
+		case MapKind:
+			visit(t.ValueType)
+			return
+		}
+
+		id := Identifier(t)
+		gvType, ok := inGroupVersion[id]
+		if !ok || visited[id] {
+			return
+		}
+
+		visited[id] = true
+		typeList = append(typeList, gvType)
+		visit(gvType.UnderlyingType)
+		for _, f := range gvType.Members() {
+			visit(f.Type)
+		}
+	}
+
+	for _, kind := range gvd.SortedKinds() {
+		visit(gvd.TypeForKind(kind))
+	}
+	for _, t := range gvd.SortedTypes() {
+		visit(t)
+	}
+
+	return typeList
+}
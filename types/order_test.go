@@ -0,0 +1,97 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+)
+
+func fieldNames(fields Fields) []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+func typeNames(typeList []*Type) []string {
+	names := make([]string, len(typeList))
+	for i, t := range typeList {
+		names[i] = t.Name
+	}
+	return names
+}
+
+func TestSortedMembers(t *testing.T) {
+	typ := &Type{Kind: StructKind, Fields: Fields{
+		{Name: "zone"},
+		{Name: "name", Required: true},
+		{Name: "count", Markers: markers.MarkerValues{OrderMarker: {-1}}},
+		{Name: "id", Required: true, Markers: markers.MarkerValues{OrderMarker: {10}}},
+	}}
+
+	require.Equal(t, []string{"zone", "name", "count", "id"}, fieldNames(typ.SortedMembers("")))
+	require.Equal(t, []string{"zone", "name", "count", "id"}, fieldNames(typ.SortedMembers(FieldOrderSource)))
+	require.Equal(t, []string{"count", "id", "name", "zone"}, fieldNames(typ.SortedMembers(FieldOrderAlphabetical)))
+	require.Equal(t, []string{"name", "id", "zone", "count"}, fieldNames(typ.SortedMembers(FieldOrderRequiredFirst)))
+	require.Equal(t, []string{"count", "zone", "name", "id"}, fieldNames(typ.SortedMembers(FieldOrderMarker)))
+
+	// The fields of the type are left untouched.
+	require.Equal(t, []string{"zone", "name", "count", "id"}, fieldNames(typ.Fields))
+}
+
+func TestRequiredField(t *testing.T) {
+	marker := func(name string) markers.MarkerValues { return markers.MarkerValues{name: {struct{}{}}} }
+
+	tests := []struct {
+		name              string
+		markers           markers.MarkerValues
+		inline            bool
+		omitEmpty         bool
+		optionalByDefault bool
+		want              bool
+	}{
+		{name: "no omitempty", want: true},
+		{name: "omitempty", omitEmpty: true},
+		{name: "inline", inline: true},
+		{name: "optional package", optionalByDefault: true},
+		{name: "kubebuilder optional", markers: marker("kubebuilder:validation:Optional")},
+		{name: "optional", markers: marker("optional")},
+		{name: "k8s optional", markers: marker("k8s:optional")},
+		{name: "kubebuilder required", markers: marker("kubebuilder:validation:Required"), omitEmpty: true, want: true},
+		{name: "required", markers: marker("required"), optionalByDefault: true, want: true},
+		{name: "k8s required", markers: marker("k8s:required"), omitEmpty: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, RequiredField(tt.markers, tt.inline, tt.omitEmpty, tt.optionalByDefault))
+		})
+	}
+}
+
+func TestOrderedTypes(t *testing.T) {
+	status := &Type{Name: "BookStatus", Package: "example.com/v1", Kind: StructKind, Order: 4}
+	entry := &Type{Name: "Entry", Package: "example.com/v1", Kind: StructKind, Order: 3}
+	spec := &Type{Name: "BookSpec", Package: "example.com/v1", Kind: StructKind, Order: 2, Fields: Fields{
+		{Name: "entries", Type: &Type{Name: "Entry", Package: "example.com/v1", Kind: SliceKind, UnderlyingType: entry}},
+	}}
+	book := &Type{Name: "Book", Package: "example.com/v1", Kind: StructKind, Order: 5, Fields: Fields{
+		{Name: "spec", Type: spec},
+		{Name: "status", Type: status},
+	}}
+	unused := &Type{Name: "Archive", Package: "example.com/v1", Kind: StructKind, Order: 1}
+
+	gvd := GroupVersionDetails{
+		GroupVersion: schema.GroupVersion{Group: "example.com", Version: "v1"},
+		Kinds:        []string{"Book"},
+		Types:        TypeMap{"Book": book, "BookSpec": spec, "BookStatus": status, "Entry": entry, "Archive": unused},
+	}
+
+	require.Equal(t, []string{"Archive", "Book", "BookSpec", "BookStatus", "Entry"}, typeNames(gvd.OrderedTypes("")))
+	require.Equal(t, []string{"Archive", "Book", "BookSpec", "BookStatus", "Entry"}, typeNames(gvd.OrderedTypes(TypeOrderAlphabetical)))
+	require.Equal(t, []string{"Archive", "BookSpec", "Entry", "BookStatus", "Book"}, typeNames(gvd.OrderedTypes(TypeOrderSource)))
+	require.Equal(t, []string{"Book", "BookSpec", "Entry", "BookStatus", "Archive"}, typeNames(gvd.OrderedTypes(TypeOrderKindsFirst)))
+}
@@ -0,0 +1,62 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+// Package markers of controller-tools exposed by GroupVersionDetails.
+const (
+	GroupNameMarker       = "groupName"
+	VersionNameMarker     = "versionName"
+	SkipMarker            = "kubebuilder:skip"
+	PackageOptionalMarker = "kubebuilder:validation:Optional"
+	PackageRequiredMarker = "kubebuilder:validation:Required"
+)
+
+// HasMarker returns true if the package of the group version has the marker with the given name.
+func (gvd GroupVersionDetails) HasMarker(name string) bool {
+	return gvd.Markers.Get(name) != nil
+}
+
+// StringMarker returns the value of the package marker with the given name, or an empty string if it is not set or
+// is not a string.
+func (gvd GroupVersionDetails) StringMarker(name string) string {
+	if v, ok := gvd.Markers.Get(name).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// GroupName returns the value of the +groupName marker.
+func (gvd GroupVersionDetails) GroupName() string {
+	return gvd.StringMarker(GroupNameMarker)
+}
+
+// VersionName returns the value of the +versionName marker, which overrides the version taken from the package name,
+// or an empty string if it is not set.
+func (gvd GroupVersionDetails) VersionName() string {
+	return gvd.StringMarker(VersionNameMarker)
+}
+
+// Skipped returns true if the package is excluded from CRD generation with the +kubebuilder:skip marker.
+func (gvd GroupVersionDetails) Skipped() bool {
+	return gvd.HasMarker(SkipMarker)
+}
+
+// FieldsOptionalByDefault returns true if the fields of the package are optional unless marked as required, as set
+// with the +kubebuilder:validation:Optional marker. Fields are required by default otherwise.
+func (gvd GroupVersionDetails) FieldsOptionalByDefault() bool {
+	return gvd.HasMarker(PackageOptionalMarker) && !gvd.HasMarker(PackageRequiredMarker)
+}
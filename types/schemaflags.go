@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+// freeFormTypes are the types whose values are arbitrary JSON.
+var freeFormTypes = map[string]struct{}{
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.JSON": {},
+	"k8s.io/apimachinery/pkg/runtime.RawExtension":                  {},
+}
+
+// SchemaFlags are the schema properties of a field or type that relax the validation of its values.
+type SchemaFlags struct {
+	// Nullable is set with the +nullable marker.
+	Nullable bool `json:"nullable,omitempty"`
+	// EmbeddedResource is set with the +kubebuilder:validation:EmbeddedResource marker.
+	EmbeddedResource bool `json:"embeddedResource,omitempty"`
+	// PreserveUnknownFields is set with the +kubebuilder:pruning:PreserveUnknownFields marker.
+	PreserveUnknownFields bool `json:"preserveUnknownFields,omitempty"`
+	// Schemaless is set with the +kubebuilder:validation:Schemaless marker.
+	Schemaless bool `json:"schemaless,omitempty"`
+	// FreeForm is set for interfaces and raw JSON types, which accept any value.
+	FreeForm bool `json:"freeForm,omitempty"`
+}
+
+// Notes explains the flags that are set, for readers of the documentation. Embedded resources are not described as
+// free-form objects, even when their type is a raw JSON type, as they must have a kind and metadata.
+func (s SchemaFlags) Notes() []string {
+	var notes []string
+	if s.FreeForm && !s.EmbeddedResource {
+		notes = append(notes, "free-form object: any JSON value is accepted")
+	}
+	if s.Schemaless {
+		notes = append(notes, "schemaless: the value is not validated")
+	}
+	if s.EmbeddedResource {
+		notes = append(notes, "embedded Kubernetes resource, with its own `apiVersion`, `kind` and `metadata`")
+	}
+	if s.PreserveUnknownFields && !s.FreeForm {
+		notes = append(notes, "unknown fields are preserved")
+	}
+	if s.Nullable {
+		notes = append(notes, "may be set to `null`")
+	}
+	return notes
+}
+
+// IsFreeForm reports whether the values of t are arbitrary JSON, such as interfaces or raw JSON types.
+func (t *Type) IsFreeForm() bool {
+	if t == nil {
+		return false
+	}
+	if _, ok := freeFormTypes[Identifier(t)]; ok {
+		return true
+	}
+
+	switch t.Kind {
+	case InterfaceKind:
+		return true
+	case AliasKind, PointerKind:
+		return t.UnderlyingType.IsFreeForm()
+	default:
+		return false
+	}
+}
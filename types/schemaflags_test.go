@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsFreeForm(t *testing.T) {
+	iface := &Type{Name: "interface{}", Kind: InterfaceKind}
+	raw := &Type{Name: "JSON", Package: "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1", Kind: StructKind}
+
+	require.True(t, iface.IsFreeForm())
+	require.True(t, raw.IsFreeForm())
+	require.True(t, (&Type{Name: "JSON", Package: raw.Package, Kind: PointerKind, UnderlyingType: raw}).IsFreeForm())
+	require.True(t, (&Type{Name: "Any", Package: "example.com/api/v1", Kind: AliasKind, UnderlyingType: iface}).IsFreeForm())
+	require.False(t, (&Type{Name: "string", Kind: BasicKind}).IsFreeForm())
+	require.False(t, (&Type{Name: "JSONs", Kind: SliceKind, UnderlyingType: raw}).IsFreeForm())
+}
+
+func TestSchemaFlagsNotes(t *testing.T) {
+	require.Empty(t, SchemaFlags{}.Notes())
+	require.Equal(t, []string{"unknown fields are preserved", "may be set to `null`"},
+		SchemaFlags{PreserveUnknownFields: true, Nullable: true}.Notes())
+	require.Equal(t, []string{"free-form object: any JSON value is accepted"},
+		SchemaFlags{FreeForm: true, PreserveUnknownFields: true}.Notes())
+	require.Equal(t, []string{"embedded Kubernetes resource, with its own `apiVersion`, `kind` and `metadata`"},
+		SchemaFlags{FreeForm: true, PreserveUnknownFields: true, EmbeddedResource: true}.Notes())
+	require.Equal(t, []string{"schemaless: the value is not validated"}, SchemaFlags{Schemaless: true}.Notes())
+}
@@ -95,22 +95,42 @@ func (k Kind) MarshalJSON() ([]byte, error) {
 
 // Type describes a declared type
 type Type struct {
-	UID            string                   `json:"uid"`
-	Name           string                   `json:"name"`
-	Package        string                   `json:"package"`
-	Doc            string                   `json:"doc"`
-	Default        string                   `json:"default"`
-	Validation     []string                 `json:"validation"`
-	Markers        markers.MarkerValues     `json:"markers"`
-	GVK            *schema.GroupVersionKind `json:"gvk"`
-	Kind           Kind                     `json:"kind"`
-	Imported       bool                     `json:"imported"`
-	UnderlyingType *Type                    `json:"underlyingType"` // for aliases, slices and pointers
-	KeyType        *Type                    `json:"keyType"`        // for maps
-	ValueType      *Type                    `json:"valueType"`      // for maps
-	Fields         Fields                   `json:"fields"`         // for structs
-	References     []*Type                  `json:"-"`              // other types that refer to this type
-	EnumValues     []EnumValue              `json:"enumValues"`     // for enum values of aliased string types
+	UID             string                   `json:"uid"`
+	Name            string                   `json:"name"`
+	Package         string                   `json:"package"`
+	Doc             string                   `json:"doc"`
+	Default         string                   `json:"default"`                // default value formatted as JSON
+	DefaultValue    any                      `json:"defaultValue,omitempty"` // default value as parsed from the marker
+	Example         any                      `json:"example,omitempty"`      // example value set with the example markers
+	Validation      []string                 `json:"validation"`             // deprecated: the rules of Validations formatted as text
+	Validations     []Validation             `json:"validations"`            // validation rules
+	Markers         markers.MarkerValues     `json:"markers"`
+	GVK             *schema.GroupVersionKind `json:"gvk"`
+	Kind            Kind                     `json:"kind"`
+	Imported        bool                     `json:"imported"`
+	UnderlyingType  *Type                    `json:"underlyingType"`  // for aliases, slices and pointers
+	KeyType         *Type                    `json:"keyType"`         // for maps
+	ValueType       *Type                    `json:"valueType"`       // for maps
+	Fields          Fields                   `json:"fields"`          // for structs
+	References      []*Type                  `json:"-"`               // other types that refer to this type
+	EnumValues      []EnumValue              `json:"enumValues"`      // for enum values of aliased string types
+	Module          *Module                  `json:"module"`          // for types declared in other modules
+	APIGroup        string                   `json:"apiGroup"`        // group set with the +groupName marker of the package
+	Order           int                      `json:"-"`               // position of the declaration, for source order
+	Title           string                   `json:"title"`           // display name set with the +crd-ref-docs:title marker
+	DocGroup        string                   `json:"docGroup"`        // group set with the +crd-ref-docs:group marker
+	ObservedState   bool                     `json:"observedState"`   // reachable only through the status of root kinds
+	ObservedStateOf []string                 `json:"observedStateOf"` // kinds whose status reaches the type
+	SchemaFlags     SchemaFlags              `json:"schemaFlags"`     // schema properties relaxing validation
+	Maturity        Maturity                 `json:"maturity"`        // maturity level set with the +crd-ref-docs:maturity marker
+	FeatureGate     string                   `json:"featureGate"`     // feature gate set with the feature gate marker
+	Deprecation     *Deprecation             `json:"deprecation"`     // set for deprecated types
+}
+
+// Module identifies the version of a Go module providing imported types.
+type Module struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
 }
 
 func (t *Type) IsBasic() bool {
@@ -128,6 +148,14 @@ func (t *Type) IsBasic() bool {
 	}
 }
 
+// DisplayName returns the title of the type if set, or its name otherwise.
+func (t *Type) DisplayName() string {
+	if t.Title != "" {
+		return t.Title
+	}
+	return t.Name
+}
+
 func (t *Type) Members() Fields {
 	if t == nil {
 		return nil
@@ -283,15 +311,37 @@ func (t *Type) propagateMarkers() {
 
 // Field describes a field in a struct.
 type Field struct {
-	Name       string
-	Aliases    []string // alternative names derived from the json "case:ignore" tag option
-	Embedded   bool     // Embedded struct in Go typing
-	Inlined    bool     // Inlined struct in serialization
-	Doc        string
-	Default    string
-	Validation []string
-	Markers    markers.MarkerValues
-	Type       *Type
+	Name     string
+	Aliases  []string // alternative names derived from the json "case:ignore" tag option
+	Embedded bool     // Embedded struct in Go typing
+	Inlined  bool     // Inlined struct in serialization
+	// Required is set when the field is required in the CRD schema, as decided by RequiredField.
+	Required bool
+	Doc      string
+	Default  string // default value formatted as JSON
+	// DefaultValue is the default value as parsed from the marker, e.g. a map[string]any for objects.
+	DefaultValue any
+	// Example is the example value set with the +kubebuilder:example or +crd-ref-docs:example marker.
+	Example    any
+	Validation []string // deprecated: the rules of Validations formatted as text
+	// Validations are the validation rules of the field.
+	Validations []Validation
+	Markers     markers.MarkerValues
+	Type        *Type
+	Title       string // display title set with the +crd-ref-docs:title marker
+	// MergeSemantics is how server-side apply merges the field, if set with the topology markers.
+	MergeSemantics *MergeSemantics
+	// SchemaFlags are the schema properties relaxing the validation of the field.
+	SchemaFlags SchemaFlags
+	DocGroup    string // group set with the +crd-ref-docs:group marker
+	// Maturity is the maturity level of the field, set with the +crd-ref-docs:maturity marker on the field or its type.
+	Maturity Maturity
+	// FeatureGate is the feature gate enabling the field, set with the feature gate marker.
+	FeatureGate string
+	// Deprecation is set for the fields deprecated with the +deprecated marker or a "Deprecated:" doc paragraph.
+	Deprecation *Deprecation
+	// Mutability are the restrictions on the updates of the field set with CEL transition rules.
+	Mutability Mutability
 }
 
 type Fields []*Field
@@ -335,21 +385,40 @@ type GroupVersionDetails struct {
 	Kinds   []string
 	Types   TypeMap
 	Markers markers.MarkerValues
+	// Title is the display name set with the +crd-ref-docs:title marker.
+	Title string
+	// DocGroup is the group of packages set with the +crd-ref-docs:group marker.
+	DocGroup string
+	// KubeTypes are the Kubernetes types referenced by Types that are documented along with them, keyed by
+	// identifier.
+	KubeTypes TypeMap
 }
 
 func (gvd GroupVersionDetails) GroupVersionString() string {
 	return gvd.GroupVersion.String()
 }
 
+// DisplayName returns the title of the group version if set, or the group version string otherwise.
+func (gvd GroupVersionDetails) DisplayName() string {
+	if gvd.Title != "" {
+		return gvd.Title
+	}
+	return gvd.GroupVersionString()
+}
+
 func (gvd GroupVersionDetails) TypeForKind(k string) *Type {
 	return gvd.Types[k]
 }
 
 func (gvd GroupVersionDetails) SortedTypes() []*Type {
-	typeList := make([]*Type, len(gvd.Types))
+	return sortedTypes(gvd.Types)
+}
+
+func sortedTypes(types TypeMap) []*Type {
+	typeList := make([]*Type, len(types))
 	i := 0
 
-	for _, t := range gvd.Types {
+	for _, t := range types {
 		typeList[i] = t
 		i++
 	}
@@ -361,6 +430,11 @@ func (gvd GroupVersionDetails) SortedTypes() []*Type {
 	return typeList
 }
 
+// SortedKubeTypes returns the documented Kubernetes types referenced by the group version, sorted by name.
+func (gvd GroupVersionDetails) SortedKubeTypes() []*Type {
+	return sortedTypes(gvd.KubeTypes)
+}
+
 func (gvd GroupVersionDetails) SortedKinds() []string {
 	if len(gvd.Kinds) <= 1 {
 		return gvd.Kinds
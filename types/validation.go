@@ -0,0 +1,145 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationKind identifies a validation rule. It is the name of the kubebuilder:validation marker setting the rule,
+// e.g. "MaxLength".
+type ValidationKind string
+
+const (
+	ValidationMinimum          ValidationKind = "Minimum"
+	ValidationMaximum          ValidationKind = "Maximum"
+	ValidationExclusiveMinimum ValidationKind = "ExclusiveMinimum"
+	ValidationExclusiveMaximum ValidationKind = "ExclusiveMaximum"
+	ValidationMultipleOf       ValidationKind = "MultipleOf"
+	ValidationMinLength        ValidationKind = "MinLength"
+	ValidationMaxLength        ValidationKind = "MaxLength"
+	ValidationPattern          ValidationKind = "Pattern"
+	ValidationFormat           ValidationKind = "Format"
+	ValidationEnum             ValidationKind = "Enum"
+	ValidationType             ValidationKind = "Type"
+	ValidationMinItems         ValidationKind = "MinItems"
+	ValidationMaxItems         ValidationKind = "MaxItems"
+	ValidationUniqueItems      ValidationKind = "UniqueItems"
+	ValidationMinProperties    ValidationKind = "MinProperties"
+	ValidationMaxProperties    ValidationKind = "MaxProperties"
+	ValidationRequired         ValidationKind = "Required"
+	ValidationOptional         ValidationKind = "Optional"
+	ValidationXValidation      ValidationKind = "XValidation"
+
+	// rules relaxing validation, also exposed as SchemaFlags
+	ValidationEmbeddedResource       ValidationKind = "EmbeddedResource"
+	ValidationXEmbeddedResource      ValidationKind = "XEmbeddedResource"
+	ValidationXPreserveUnknownFields ValidationKind = "XPreserveUnknownFields"
+	ValidationSchemaless             ValidationKind = "Schemaless"
+)
+
+// Validation is a validation rule of a field or type.
+type Validation struct {
+	Kind ValidationKind `json:"kind"`
+	// Value is the value of the marker, e.g. the maximum length.
+	Value any `json:"value,omitempty"`
+	// Marker is the name of the marker setting the rule.
+	Marker string `json:"marker"`
+	// Items is set for the rules applying to the items of a list rather than to the list itself.
+	Items bool `json:"items,omitempty"`
+	// From is the name of the type declaring the rule, for the rules inherited from a type.
+	From string `json:"from,omitempty"`
+	// Conflict is set when other rules of the same kind have a different value.
+	Conflict bool `json:"conflict,omitempty"`
+}
+
+// String formats the rule as "Kind: value", e.g. "MaxLength: 10".
+func (v Validation) String() string {
+	name := string(v.Kind)
+	if v.Items {
+		name = "items:" + name
+	}
+
+	if v.Kind == ValidationPattern {
+		return fmt.Sprintf("%s: `%v`", name, v.Value)
+	}
+	return fmt.Sprintf("%s: %v", name, v.Value)
+}
+
+// ValidationStrings formats the rules with their String method, leaving out the rules that are too long to be
+// readable as text, e.g. CEL rules.
+func ValidationStrings(validations []Validation) []string {
+	strs := []string{}
+	for _, v := range validations {
+		// FIXME: XValidation currently removed due to being long and difficult to read.
+		// E.g. "XValidation: {self.page < 200 Please start a new book.}"
+		if v.Kind == ValidationXValidation {
+			continue
+		}
+		strs = append(strs, v.String())
+	}
+	return strs
+}
+
+// MarkConflicts dedupes the rules of the same kind and value, keeping the first one, and flags the rules of the same
+// kind declared with different values by the same type as conflicts. Rules declared by different types all apply, so
+// they never conflict, and neither do CEL rules, which can be combined.
+func MarkConflicts(validations []Validation) []Validation {
+	type key struct {
+		kind  ValidationKind
+		items bool
+	}
+	type sourceKey struct {
+		key
+		from string
+	}
+
+	deduped := make([]Validation, 0, len(validations))
+	values := make(map[key]map[string]struct{})
+	counts := make(map[sourceKey]int)
+	for _, v := range validations {
+		k := key{kind: v.Kind, items: v.Items}
+		value := fmt.Sprint(v.Value)
+		if _, ok := values[k][value]; ok {
+			continue
+		}
+		if values[k] == nil {
+			values[k] = make(map[string]struct{})
+		}
+		values[k][value] = struct{}{}
+		counts[sourceKey{key: k, from: v.From}]++
+		deduped = append(deduped, v)
+	}
+
+	for i, v := range deduped {
+		if v.Kind != ValidationXValidation && counts[sourceKey{key: key{kind: v.Kind, items: v.Items}, from: v.From}] > 1 {
+			deduped[i].Conflict = true
+		}
+	}
+
+	return deduped
+}
+
+// ParseValidationKind returns the kind of the rule set by the kubebuilder:validation marker with the given name,
+// stripped of its prefix, and whether it applies to the items of a list.
+func ParseValidationKind(name string) (ValidationKind, bool) {
+	if kind, ok := strings.CutPrefix(name, "items:"); ok {
+		return ValidationKind(kind), true
+	}
+	return ValidationKind(name), false
+}
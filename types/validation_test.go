@@ -0,0 +1,50 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationStrings(t *testing.T) {
+	validations := []Validation{
+		{Kind: ValidationMaxLength, Value: 10, Marker: "kubebuilder:validation:MaxLength"},
+		{Kind: ValidationPattern, Value: "[a-z]*", Marker: "kubebuilder:validation:items:Pattern", Items: true},
+		{Kind: ValidationXValidation, Value: "self.page < 200", Marker: "kubebuilder:validation:XValidation"},
+		{Kind: ValidationRequired, Value: struct{}{}, Marker: "required"},
+	}
+
+	require.Equal(t, []string{"MaxLength: 10", "items:Pattern: `[a-z]*`", "Required: {}"}, ValidationStrings(validations))
+	require.Equal(t, []string{}, ValidationStrings(nil))
+}
+
+func TestParseValidationKind(t *testing.T) {
+	kind, items := ParseValidationKind("MaxLength")
+	require.Equal(t, ValidationMaxLength, kind)
+	require.False(t, items)
+
+	kind, items = ParseValidationKind("items:Pattern")
+	require.Equal(t, ValidationPattern, kind)
+	require.True(t, items)
+}
+
+func TestMarkConflicts(t *testing.T) {
+	validations := []Validation{
+		{Kind: ValidationMaxLength, Value: 10},
+		{Kind: ValidationMaxLength, Value: 10, From: "Name"},
+		{Kind: ValidationMaxLength, Value: 20, From: "Name"},
+		{Kind: ValidationMaximum, Value: 4, From: "Rating"},
+		{Kind: ValidationMaximum, Value: 5, From: "Rating"},
+		{Kind: ValidationXValidation, Value: "self > 0", From: "Rating"},
+		{Kind: ValidationXValidation, Value: "self < 9", From: "Rating"},
+	}
+
+	require.Equal(t, []Validation{
+		{Kind: ValidationMaxLength, Value: 10},
+		{Kind: ValidationMaxLength, Value: 20, From: "Name"},
+		{Kind: ValidationMaximum, Value: 4, From: "Rating", Conflict: true},
+		{Kind: ValidationMaximum, Value: 5, From: "Rating", Conflict: true},
+		{Kind: ValidationXValidation, Value: "self > 0", From: "Rating"},
+		{Kind: ValidationXValidation, Value: "self < 9", From: "Rating"},
+	}, MarkConflicts(validations))
+}
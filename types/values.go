@@ -0,0 +1,221 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// DefaultString formats a default value as compact JSON, e.g. {"page":1}, or returns an empty string if there is no
+// default value. The keys of ordered objects (yaml.MapSlice), as decoded from example blocks, keep their order.
+func DefaultString(value any) string {
+	if value == nil {
+		return ""
+	}
+	b, err := json.Marshal(jsonValue(value))
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// CheckValue reports whether a value, such as a default or an example, can be decoded as a value of t. Imported and
+// free-form types, whose JSON form is not known, accept any value.
+func CheckValue(value any, t *Type) error {
+	for t != nil && (t.Kind == AliasKind || t.Kind == PointerKind) && !t.Imported && !t.IsFreeForm() {
+		t = t.UnderlyingType
+	}
+	if t == nil || t.Imported || t.IsFreeForm() {
+		return nil
+	}
+
+	switch t.Kind {
+	case BasicKind:
+		return checkBasicValue(value, t.Name)
+	case SliceKind:
+		if t.UnderlyingType != nil && t.UnderlyingType.Kind == BasicKind && t.UnderlyingType.Name == "byte" {
+			return checkBasicValue(value, "string")
+		}
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice {
+			return mismatch(value, "list")
+		}
+		for i := range items.Len() {
+			if err := CheckValue(items.Index(i).Interface(), t.UnderlyingType); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		return nil
+	case MapKind:
+		entries, ok := toObject(value)
+		if !ok {
+			return mismatch(value, "object")
+		}
+		for key, entry := range entries {
+			if err := CheckValue(entry, t.ValueType); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+		return nil
+	case StructKind:
+		entries, ok := toObject(value)
+		if !ok {
+			return mismatch(value, "object")
+		}
+		return checkStructValue(entries, t)
+	default:
+		return nil
+	}
+}
+
+// checkStructValue checks the entries of an object against the fields of a struct, unless some fields are
+// inlined, as their own fields are not known.
+func checkStructValue(entries map[string]any, t *Type) error {
+	fields := make(map[string]*Field, len(t.Fields))
+	for _, f := range t.Fields {
+		if f.Inlined || f.Embedded {
+			return nil
+		}
+		fields[f.Name] = f
+	}
+
+	for key, entry := range entries {
+		f, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("unknown field %q", key)
+		}
+		if err := CheckValue(entry, f.Type); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func checkBasicValue(value any, name string) error {
+	switch {
+	case name == "string":
+		if _, ok := value.(string); !ok {
+			return mismatch(value, "string")
+		}
+	case name == "bool":
+		if _, ok := value.(bool); !ok {
+			return mismatch(value, "boolean")
+		}
+	case strings.HasPrefix(name, "float"):
+		if _, ok := toFloat(value); !ok {
+			return mismatch(value, "number")
+		}
+	case strings.HasPrefix(name, "int"), strings.HasPrefix(name, "uint"), name == "byte", name == "rune":
+		if f, ok := toFloat(value); !ok || f != math.Trunc(f) {
+			return mismatch(value, "integer")
+		}
+	}
+	return nil
+}
+
+func toFloat(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// toObject returns the entries of a map with string keys, as decoded from markers or YAML.
+func toObject(value any) (map[string]any, bool) {
+	if ordered, ok := value.(yaml.MapSlice); ok {
+		entries := make(map[string]any, len(ordered))
+		for _, item := range ordered {
+			key, ok := item.Key.(string)
+			if !ok {
+				return nil, false
+			}
+			entries[key] = item.Value
+		}
+		return entries, true
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map {
+		return nil, false
+	}
+
+	entries := make(map[string]any, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key, ok := iter.Key().Interface().(string)
+		if !ok {
+			return nil, false
+		}
+		entries[key] = iter.Value().Interface()
+	}
+	return entries, true
+}
+
+// orderedObject encodes the entries of an ordered object as a JSON object, in order.
+type orderedObject yaml.MapSlice
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	buf := []byte{'{'}
+	for i, item := range o {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		key, err := json.Marshal(fmt.Sprint(item.Key))
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(jsonValue(item.Value))
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(append(buf, key...), ':'), value...)
+	}
+	return append(buf, '}'), nil
+}
+
+// jsonValue returns value with its ordered objects replaced by values encoding them in order.
+func jsonValue(value any) any {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		return orderedObject(v)
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = jsonValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+func mismatch(value any, expected string) error {
+	return fmt.Errorf("%s is not a valid %s", DefaultString(value), expected)
+}
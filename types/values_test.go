@@ -0,0 +1,40 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultString(t *testing.T) {
+	require.Equal(t, "", DefaultString(nil))
+	require.Equal(t, `"b"`, DefaultString("b"))
+	require.Equal(t, `{"page":1,"tags":["a"]}`, DefaultString(map[string]any{"tags": []string{"a"}, "page": 1}))
+}
+
+func TestCheckValue(t *testing.T) {
+	str := &Type{Name: "string", Kind: BasicKind}
+	integer := &Type{Name: "int32", Kind: BasicKind}
+	name := &Type{Name: "Name", Kind: AliasKind, UnderlyingType: str}
+	spec := &Type{Name: "Spec", Kind: StructKind, Fields: Fields{
+		{Name: "page", Type: &Type{Kind: PointerKind, UnderlyingType: integer}},
+		{Name: "tags", Type: &Type{Kind: SliceKind, UnderlyingType: name}},
+	}}
+
+	require.NoError(t, CheckValue("b", name))
+	require.NoError(t, CheckValue(2.0, integer))
+	require.NoError(t, CheckValue(uint64(2), integer))
+	require.NoError(t, CheckValue(map[string]any{"tags": []any{"a"}}, spec))
+	require.NoError(t, CheckValue(map[string]any{"page": 1, "tags": []string{"a"}}, spec))
+	require.NoError(t, CheckValue(yaml.MapSlice{{Key: "tags", Value: []any{"a"}}, {Key: "page", Value: 1}}, spec))
+	require.NoError(t, CheckValue(1, &Type{Name: "Time", Kind: StructKind, Imported: true}))
+	require.NoError(t, CheckValue(1, &Type{Kind: InterfaceKind}))
+
+	require.EqualError(t, CheckValue(1, name), "1 is not a valid string")
+	require.EqualError(t, CheckValue(2.5, integer), "2.5 is not a valid integer")
+	require.EqualError(t, CheckValue(map[string]any{"size": 1}, spec), `unknown field "size"`)
+	require.EqualError(t, CheckValue(map[string]any{"tags": []any{"a", 1}}, spec), "tags: [1]: 1 is not a valid string")
+	require.EqualError(t, CheckValue(yaml.MapSlice{{Key: "size", Value: 1}}, spec), `unknown field "size"`)
+	require.EqualError(t, CheckValue("a", spec), `"a" is not a valid object`)
+}